@@ -0,0 +1,128 @@
+package eip712
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// PieceMetadata pairs one piece's metadata keys/values for signing as part
+// of an AddPieces typed-data message. Index mirrors the piece's position in
+// the pieces array passed to the PDPVerifier call, the same ordering
+// ExtraDataEncoder.EncodeAddPiecesExtraData packs.
+type PieceMetadata struct {
+	Keys   []string
+	Values []string
+}
+
+// BuildCreateDataSet constructs the typed-data object a payer signs to
+// authorize creating a data set. Its fields mirror the ones
+// ExtraDataEncoder.EncodeCreateDataSetExtraData packs into extraData.
+func (b *TypedDataBuilder) BuildCreateDataSet(payer common.Address, clientDataSetId *big.Int, metadata []MetadataEntry) apitypes.TypedData {
+	keys, values := MetadataToStringSlices(metadata)
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": eip712DomainType,
+			"CreateDataSet": {
+				{Name: "payer", Type: "address"},
+				{Name: "clientDataSetId", Type: "uint256"},
+				{Name: "metadataKeys", Type: "string[]"},
+				{Name: "metadataValues", Type: "string[]"},
+			},
+		},
+		PrimaryType: "CreateDataSet",
+		Domain:      b.domain.toTypedDataDomain(),
+		Message: apitypes.TypedDataMessage{
+			"payer":           payer.Hex(),
+			"clientDataSetId": clientDataSetId.String(),
+			"metadataKeys":    keys,
+			"metadataValues":  values,
+		},
+	}
+}
+
+// BuildAddPieces constructs the typed-data object a payer signs to authorize
+// adding pieces to a data set. Its fields mirror the ones
+// ExtraDataEncoder.EncodeAddPiecesExtraData packs into extraData.
+func (b *TypedDataBuilder) BuildAddPieces(clientDataSetId, nonce *big.Int, metadata [][]MetadataEntry) apitypes.TypedData {
+	pieces := make([]map[string]interface{}, len(metadata))
+	for i, pieceMetadata := range metadata {
+		keys, values := MetadataToStringSlices(pieceMetadata)
+		pieces[i] = map[string]interface{}{
+			"pieceIndex": big.NewInt(int64(i)).String(),
+			"keys":       keys,
+			"values":     values,
+		}
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": eip712DomainType,
+			"AddPieces": {
+				{Name: "clientDataSetId", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "pieces", Type: "PieceMetadata[]"},
+			},
+			"PieceMetadata": {
+				{Name: "pieceIndex", Type: "uint256"},
+				{Name: "keys", Type: "string[]"},
+				{Name: "values", Type: "string[]"},
+			},
+		},
+		PrimaryType: "AddPieces",
+		Domain:      b.domain.toTypedDataDomain(),
+		Message: apitypes.TypedDataMessage{
+			"clientDataSetId": clientDataSetId.String(),
+			"nonce":           nonce.String(),
+			"pieces":          pieces,
+		},
+	}
+}
+
+// BuildSchedulePieceRemovals constructs the typed-data object a payer signs
+// to authorize removing pieceIds from a data set.
+func (b *TypedDataBuilder) BuildSchedulePieceRemovals(clientDataSetId, nonce *big.Int, pieceIds []*big.Int) apitypes.TypedData {
+	ids := make([]string, len(pieceIds))
+	for i, id := range pieceIds {
+		ids[i] = id.String()
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": eip712DomainType,
+			"SchedulePieceRemovals": {
+				{Name: "clientDataSetId", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "pieceIds", Type: "uint256[]"},
+			},
+		},
+		PrimaryType: "SchedulePieceRemovals",
+		Domain:      b.domain.toTypedDataDomain(),
+		Message: apitypes.TypedDataMessage{
+			"clientDataSetId": clientDataSetId.String(),
+			"nonce":           nonce.String(),
+			"pieceIds":        ids,
+		},
+	}
+}
+
+// BuildDeleteDataSet constructs the typed-data object a payer signs to
+// authorize deleting a data set.
+func (b *TypedDataBuilder) BuildDeleteDataSet(clientDataSetId, nonce *big.Int) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": eip712DomainType,
+			"DeleteDataSet": {
+				{Name: "clientDataSetId", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "DeleteDataSet",
+		Domain:      b.domain.toTypedDataDomain(),
+		Message: apitypes.TypedDataMessage{
+			"clientDataSetId": clientDataSetId.String(),
+			"nonce":           nonce.String(),
+		},
+	}
+}
@@ -0,0 +1,102 @@
+package eip712
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Domain identifies the EIP-712 signing domain of a deployed
+// FilecoinWarmStorageService instance. It binds every signature produced by
+// a TypedDataBuilder to a specific contract name, version, chain, and
+// address, so a signature authorized for one deployment cannot be replayed
+// against another (wrong network, forked chain, or an upgraded contract).
+type Domain struct {
+	Name              string
+	Version           string
+	ChainID           *big.Int
+	VerifyingContract common.Address
+}
+
+// eip712DomainType is the "EIP712Domain" type used to hash a Domain. The
+// contract's domain omits "salt", matching the fields its DOMAIN_SEPARATOR()
+// view is expected to commit to.
+var eip712DomainType = []apitypes.Type{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+}
+
+func (d Domain) toTypedDataDomain() apitypes.TypedDataDomain {
+	return apitypes.TypedDataDomain{
+		Name:              d.Name,
+		Version:           d.Version,
+		ChainId:           (*math.HexOrDecimal256)(d.ChainID),
+		VerifyingContract: d.VerifyingContract.Hex(),
+	}
+}
+
+// Hash computes the EIP-712 domain separator for d entirely offline. Compare
+// it against a contract's on-chain DOMAIN_SEPARATOR() value (see
+// contract.QueryDomainSeparator) before trusting any signature bound to d.
+func (d Domain) Hash() (common.Hash, error) {
+	td := apitypes.TypedData{
+		Types:       apitypes.Types{"EIP712Domain": eip712DomainType},
+		PrimaryType: "EIP712Domain",
+		Domain:      d.toTypedDataDomain(),
+	}
+
+	hash, err := td.HashStruct("EIP712Domain", td.Domain.Map())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("hashing EIP-712 domain: %w", err)
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// Digest returns the EIP-712 signing hash for typedData: the bytes32 value
+// passed to crypto.Sign (or recovered on-chain via ecrecover). Centralizing
+// this here keeps every TypedDataSigner implementation from re-deriving it.
+func Digest(typedData apitypes.TypedData) (common.Hash, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("hashing typed data: %w", err)
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// TypedDataBuilder builds the EIP-712 typed-data objects that storage
+// clients sign to authorize PDP operations on a specific
+// FilecoinWarmStorageService deployment. A builder is bound to one Domain;
+// prefer NewVerifiedTypedDataBuilder whenever a live RPC connection is
+// available.
+type TypedDataBuilder struct {
+	domain Domain
+}
+
+// NewTypedDataBuilder returns a builder for domain without checking it
+// against an on-chain DOMAIN_SEPARATOR(). Useful offline (tests, simulated
+// backends) where there is nothing to cross-check against.
+func NewTypedDataBuilder(domain Domain) *TypedDataBuilder {
+	return &TypedDataBuilder{domain: domain}
+}
+
+// NewVerifiedTypedDataBuilder returns a builder for domain after confirming
+// that the locally-computed domain separator matches onChainDomainSeparator
+// (the value returned by the contract's DOMAIN_SEPARATOR() view). It refuses
+// to build typed data - and therefore refuses to let anything be signed - if
+// the two disagree, catching mistakes like signing against the wrong network
+// or a since-upgraded contract before a signature is ever produced.
+func NewVerifiedTypedDataBuilder(domain Domain, onChainDomainSeparator common.Hash) (*TypedDataBuilder, error) {
+	localHash, err := domain.Hash()
+	if err != nil {
+		return nil, err
+	}
+	if localHash != onChainDomainSeparator {
+		return nil, fmt.Errorf("eip712: domain separator mismatch: computed %s but contract reports %s (wrong network or upgraded contract?)", localHash, onChainDomainSeparator)
+	}
+	return &TypedDataBuilder{domain: domain}, nil
+}
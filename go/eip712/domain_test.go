@@ -0,0 +1,75 @@
+package eip712
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func testDomain() Domain {
+	return Domain{
+		Name:              "FilecoinWarmStorageService",
+		Version:           "1",
+		ChainID:           big.NewInt(314159),
+		VerifyingContract: common.HexToAddress("0x1111111111111111111111111111111111111111"),
+	}
+}
+
+// TestDomainHashIsDeterministic verifies that hashing the same Domain twice
+// yields the same digest, and that changing any field changes the hash.
+func TestDomainHashIsDeterministic(t *testing.T) {
+	domain := testDomain()
+
+	hash1, err := domain.Hash()
+	require.NoError(t, err)
+
+	hash2, err := domain.Hash()
+	require.NoError(t, err)
+	require.Equal(t, hash1, hash2)
+
+	other := domain
+	other.ChainID = big.NewInt(1)
+	otherHash, err := other.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, hash1, otherHash)
+}
+
+// TestNewVerifiedTypedDataBuilderRejectsMismatch verifies the builder refuses
+// to be constructed when the on-chain domain separator disagrees with the
+// locally computed one, guarding against wrong-network/upgraded-contract
+// mistakes before anything is signed.
+func TestNewVerifiedTypedDataBuilderRejectsMismatch(t *testing.T) {
+	domain := testDomain()
+
+	localHash, err := domain.Hash()
+	require.NoError(t, err)
+
+	_, err = NewVerifiedTypedDataBuilder(domain, localHash)
+	require.NoError(t, err)
+
+	_, err = NewVerifiedTypedDataBuilder(domain, common.Hash{0xde, 0xad})
+	require.Error(t, err)
+}
+
+// TestBuildCreateDataSetBindsDomainAndFields verifies that BuildCreateDataSet
+// produces typed data bound to the builder's domain and carrying the fields
+// ExtraDataEncoder.EncodeCreateDataSetExtraData packs.
+func TestBuildCreateDataSetBindsDomainAndFields(t *testing.T) {
+	builder := NewTypedDataBuilder(testDomain())
+
+	payer := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	clientDataSetId := big.NewInt(7)
+	metadata := []MetadataEntry{{Key: "label", Value: "integration"}}
+
+	typedData := builder.BuildCreateDataSet(payer, clientDataSetId, metadata)
+
+	require.Equal(t, "CreateDataSet", typedData.PrimaryType)
+	require.Equal(t, payer.Hex(), typedData.Message["payer"])
+	require.Equal(t, clientDataSetId.String(), typedData.Message["clientDataSetId"])
+
+	digest, err := Digest(typedData)
+	require.NoError(t, err)
+	require.NotEqual(t, common.Hash{}, digest)
+}
@@ -54,8 +54,12 @@ func (e *ExtraDataEncoder) EncodeCreateDataSetExtraData(
 	payer common.Address,
 	clientDataSetId *big.Int,
 	metadata []MetadataEntry,
-	signature *AuthSignature,
+	signed *SignedTypedData,
 ) ([]byte, error) {
+	if err := requirePrimaryType(signed, "CreateDataSet"); err != nil {
+		return nil, err
+	}
+
 	// Split metadata into keys and values arrays
 	keys := make([]string, len(metadata))
 	values := make([]string, len(metadata))
@@ -64,7 +68,20 @@ func (e *ExtraDataEncoder) EncodeCreateDataSetExtraData(
 		values[i] = m.Value
 	}
 
-	signatureBytes, err := signature.Marshal()
+	if err := requireMessageField(signed, "payer", payer.Hex()); err != nil {
+		return nil, err
+	}
+	if err := requireMessageField(signed, "clientDataSetId", clientDataSetId.String()); err != nil {
+		return nil, err
+	}
+	if err := requireMessageStringSlice(signed, "metadataKeys", keys); err != nil {
+		return nil, err
+	}
+	if err := requireMessageStringSlice(signed, "metadataValues", values); err != nil {
+		return nil, err
+	}
+
+	signatureBytes, err := signed.Signature.Marshal()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal signature: %w", err)
 	}
@@ -85,10 +102,20 @@ func (e *ExtraDataEncoder) EncodeCreateDataSetExtraData(
 // Format matches: abi.decode(extraData, (uint256, string[][], string[][], bytes))
 func (e *ExtraDataEncoder) EncodeAddPiecesExtraData(
 	nonce *big.Int,
-	signature *AuthSignature,
+	signed *SignedTypedData,
 	metadata [][]MetadataEntry,
 ) ([]byte, error) {
-	signatureBytes, err := signature.Marshal()
+	if err := requirePrimaryType(signed, "AddPieces"); err != nil {
+		return nil, err
+	}
+	if err := requireMessageField(signed, "nonce", nonce.String()); err != nil {
+		return nil, err
+	}
+	if err := requireMessagePieces(signed, metadata); err != nil {
+		return nil, err
+	}
+
+	signatureBytes, err := signed.Signature.Marshal()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal signature: %w", err)
 	}
@@ -120,9 +147,13 @@ func (e *ExtraDataEncoder) EncodeAddPiecesExtraData(
 // EncodeSchedulePieceRemovalsExtraData encodes the extraData for piecesScheduledRemove callback
 // Format matches: abi.decode(extraData, (bytes))
 func (e *ExtraDataEncoder) EncodeSchedulePieceRemovalsExtraData(
-	signature *AuthSignature,
+	signed *SignedTypedData,
 ) ([]byte, error) {
-	signatureBytes, err := signature.Marshal()
+	if err := requirePrimaryType(signed, "SchedulePieceRemovals"); err != nil {
+		return nil, err
+	}
+
+	signatureBytes, err := signed.Signature.Marshal()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal signature: %w", err)
 	}
@@ -136,10 +167,162 @@ func (e *ExtraDataEncoder) EncodeSchedulePieceRemovalsExtraData(
 
 // EncodeDeleteDataSetExtraData encodes the extraData for dataSetDeleted callback
 func (e *ExtraDataEncoder) EncodeDeleteDataSetExtraData(
-	signature *AuthSignature,
+	signed *SignedTypedData,
 ) ([]byte, error) {
-	// Same as SchedulePieceRemovals - just a signature
-	return e.EncodeSchedulePieceRemovalsExtraData(signature)
+	if err := requirePrimaryType(signed, "DeleteDataSet"); err != nil {
+		return nil, err
+	}
+
+	signatureBytes, err := signed.Signature.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signature: %w", err)
+	}
+
+	arguments := abi.Arguments{
+		{Type: bytesType}, // signature
+	}
+
+	return arguments.Pack(signatureBytes)
+}
+
+// requirePrimaryType guards against packing a signature that was produced
+// for a different TypedDataBuilder operation than the Encode*ExtraData
+// method being called, so a CreateDataSet signature (say) can never be
+// silently repacked as a DeleteDataSet authorization.
+func requirePrimaryType(signed *SignedTypedData, primaryType string) error {
+	if signed == nil {
+		return fmt.Errorf("signed typed data is required")
+	}
+	if signed.TypedData.PrimaryType != primaryType {
+		return fmt.Errorf("signed typed data is for %q, expected %q", signed.TypedData.PrimaryType, primaryType)
+	}
+	return nil
+}
+
+// requireMessageField guards against packing a signature whose signed
+// message doesn't actually cover the plaintext argument an Encode*ExtraData
+// method is about to pack alongside it - e.g. a signature that authorized
+// clientDataSetId 7 being repacked with clientDataSetId 8 supplied as a
+// plain (unsigned) argument. field is looked up in signed.TypedData.Message,
+// the same apitypes.TypedDataMessage a TypedDataBuilder.Build* method
+// populates, and compared against want's canonical string encoding.
+func requireMessageField(signed *SignedTypedData, field, want string) error {
+	got, ok := signed.TypedData.Message[field]
+	if !ok {
+		return fmt.Errorf("signed typed data is missing message field %q", field)
+	}
+	gotStr, ok := got.(string)
+	if !ok || gotStr != want {
+		return fmt.Errorf("signed typed data message field %q is %v, expected %q", field, got, want)
+	}
+	return nil
+}
+
+// requireMessageStringSlice is requireMessageField for a string[] message
+// field, tolerating both the []string a TypedDataBuilder.Build* method
+// produces directly and the []interface{} of strings a SignedTypedData
+// decoded from JSON would carry instead.
+func requireMessageStringSlice(signed *SignedTypedData, field string, want []string) error {
+	got, ok := signed.TypedData.Message[field]
+	if !ok {
+		return fmt.Errorf("signed typed data is missing message field %q", field)
+	}
+	gotSlice, ok := toStringSlice(got)
+	if !ok || !stringSlicesEqual(gotSlice, want) {
+		return fmt.Errorf("signed typed data message field %q is %v, expected %v", field, got, want)
+	}
+	return nil
+}
+
+// requireMessagePieces checks that the signed "pieces" message field built
+// by TypedDataBuilder.BuildAddPieces - one {pieceIndex, keys, values} entry
+// per element of metadata, in order - matches metadata itself.
+func requireMessagePieces(signed *SignedTypedData, metadata [][]MetadataEntry) error {
+	const field = "pieces"
+
+	got, ok := signed.TypedData.Message[field]
+	if !ok {
+		return fmt.Errorf("signed typed data is missing message field %q", field)
+	}
+	pieces, ok := toInterfaceSlice(got)
+	if !ok || len(pieces) != len(metadata) {
+		return fmt.Errorf("signed typed data message field %q does not match %d piece(s) of metadata", field, len(metadata))
+	}
+
+	for i, pieceMetadata := range metadata {
+		keys, values := MetadataToStringSlices(pieceMetadata)
+
+		piece, ok := pieces[i].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("signed typed data message field %q[%d] is not an object", field, i)
+		}
+
+		wantIndex := big.NewInt(int64(i)).String()
+		if gotIndex, ok := piece["pieceIndex"].(string); !ok || gotIndex != wantIndex {
+			return fmt.Errorf("signed typed data message field %q[%d].pieceIndex is %v, expected %q", field, i, piece["pieceIndex"], wantIndex)
+		}
+
+		gotKeys, ok := toStringSlice(piece["keys"])
+		if !ok || !stringSlicesEqual(gotKeys, keys) {
+			return fmt.Errorf("signed typed data message field %q[%d].keys is %v, expected %v", field, i, piece["keys"], keys)
+		}
+		gotValues, ok := toStringSlice(piece["values"])
+		if !ok || !stringSlicesEqual(gotValues, values) {
+			return fmt.Errorf("signed typed data message field %q[%d].values is %v, expected %v", field, i, piece["values"], values)
+		}
+	}
+	return nil
+}
+
+// toStringSlice accepts either a []string (a Build* method's native return)
+// or a []interface{} of strings (what the same field decodes to out of
+// JSON), normalizing both to []string.
+func toStringSlice(v interface{}) ([]string, bool) {
+	switch vals := v.(type) {
+	case []string:
+		return vals, true
+	case []interface{}:
+		out := make([]string, len(vals))
+		for i, val := range vals {
+			s, ok := val.(string)
+			if !ok {
+				return nil, false
+			}
+			out[i] = s
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+// toInterfaceSlice is toStringSlice's counterpart for the "pieces" field,
+// whose elements are themselves objects rather than strings.
+func toInterfaceSlice(v interface{}) ([]interface{}, bool) {
+	switch vals := v.(type) {
+	case []interface{}:
+		return vals, true
+	case []map[string]interface{}:
+		out := make([]interface{}, len(vals))
+		for i, m := range vals {
+			out[i] = m
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 // ParseMetadataEntries converts a slice of key=value strings to MetadataEntry slice
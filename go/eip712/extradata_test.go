@@ -7,10 +7,22 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/storacha/filecoin-services/go/bindings"
 	"github.com/stretchr/testify/require"
 )
 
+// signedFor wraps signature as a SignedTypedData carrying typedData,
+// matching what a real TypedDataBuilder + TypedDataSigner round trip would
+// produce - including the Message an Encode*ExtraData method now checks
+// against its plaintext arguments.
+func signedFor(typedData apitypes.TypedData, signature *AuthSignature) *SignedTypedData {
+	return &SignedTypedData{
+		TypedData: typedData,
+		Signature: signature,
+	}
+}
+
 // TestEncodingMatchesContractDecoding verifies our encoding matches what the contract expects
 func TestEncodingMatchesContractDecoding(t *testing.T) {
 	encoder := NewExtraDataEncoder()
@@ -22,7 +34,10 @@ func TestEncodingMatchesContractDecoding(t *testing.T) {
 		metadata := []MetadataEntry{{Key: "test", Value: "data"}}
 		signature := &AuthSignature{V: 27, R: [32]byte{1}, S: [32]byte{2}}
 
-		encoded, err := encoder.EncodeCreateDataSetExtraData(payer, clientDataSetId, metadata, signature)
+		builder := NewTypedDataBuilder(testDomain())
+		typedData := builder.BuildCreateDataSet(payer, clientDataSetId, metadata)
+
+		encoded, err := encoder.EncodeCreateDataSetExtraData(payer, clientDataSetId, metadata, signedFor(typedData, signature))
 		require.NoError(t, err)
 
 		// Decode using the same format the contract expects
@@ -54,8 +69,12 @@ func TestEncodingMatchesContractDecoding(t *testing.T) {
 			{{Key: "piece1", Value: "value1"}},
 			{{Key: "piece2", Value: "value2"}},
 		}
+		nonce := big.NewInt(1)
+
+		builder := NewTypedDataBuilder(testDomain())
+		typedData := builder.BuildAddPieces(big.NewInt(42), nonce, metadata)
 
-		encoded, err := encoder.EncodeAddPiecesExtraData(signature, metadata)
+		encoded, err := encoder.EncodeAddPiecesExtraData(nonce, signedFor(typedData, signature), metadata)
 		require.NoError(t, err)
 
 		// Decode using the contract's expected format
@@ -89,7 +108,8 @@ func TestEncodingMatchesContractDecoding(t *testing.T) {
 	t.Run("SchedulePieceRemovals", func(t *testing.T) {
 		signature := &AuthSignature{V: 27, R: [32]byte{1}, S: [32]byte{2}}
 
-		encoded, err := encoder.EncodeSchedulePieceRemovalsExtraData(signature)
+		signed := signedFor(apitypes.TypedData{PrimaryType: "SchedulePieceRemovals"}, signature)
+		encoded, err := encoder.EncodeSchedulePieceRemovalsExtraData(signed)
 		require.NoError(t, err)
 
 		// Decode using the contract's expected format
@@ -111,10 +131,11 @@ func TestEncodingMatchesContractDecoding(t *testing.T) {
 	t.Run("DeleteDataSet", func(t *testing.T) {
 		signature := &AuthSignature{V: 27, R: [32]byte{1}, S: [32]byte{2}}
 
-		encoded, err := encoder.EncodeDeleteDataSetExtraData(signature)
+		signed := signedFor(apitypes.TypedData{PrimaryType: "DeleteDataSet"}, signature)
+		encoded, err := encoder.EncodeDeleteDataSetExtraData(signed)
 		require.NoError(t, err)
 
-		// Should be the same as SchedulePieceRemovals
+		// Encoded layout is the same as SchedulePieceRemovals
 		arguments := abi.Arguments{
 			{Type: bytesType},
 		}
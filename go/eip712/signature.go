@@ -2,8 +2,18 @@ package eip712
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
+// SignedTypedData pairs a signature with the EIP-712 typed-data object it
+// was produced for (e.g. via TypedDataBuilder and a TypedDataSigner), so
+// ExtraDataEncoder only ever packs signatures that came from an actual
+// domain-bound signing round trip rather than an ad-hoc *AuthSignature.
+type SignedTypedData struct {
+	TypedData apitypes.TypedData
+	Signature *AuthSignature
+}
+
 type AuthSignature struct {
 	Signature  []byte         `json:"signature"`
 	V          uint8          `json:"v"`
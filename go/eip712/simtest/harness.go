@@ -0,0 +1,91 @@
+// Package simtest spins up an in-process Ethereum simulated backend and
+// deploys the real FilecoinWarmStorageService bytecode, so eip712 encoder
+// tests can be fed through the actual Solidity abi.decode(...) path instead
+// of only re-decoding with the same Go-side ABI types.
+package simtest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+)
+
+// Harness wires up a simulated chain with a deployed FilecoinWarmStorageService
+// and a funded key that plays the role of the PDP verifier caller.
+type Harness struct {
+	Backend      *simulated.Backend
+	Contract     *bindings.FilecoinWarmStorageService
+	ContractABI  *abi.ABI
+	VerifierKey  *ecdsa.PrivateKey
+	VerifierAuth *bind.TransactOpts
+}
+
+// New deploys the contract on a fresh simulated backend funded with a single
+// verifier account, which is the account that the real PDPVerifier contract
+// would call the dataSetCreated/piecesAdded/... callbacks from.
+func New(ctx context.Context) (*Harness, error) {
+	verifierKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("generating verifier key: %w", err)
+	}
+	verifierAddr := crypto.PubkeyToAddress(verifierKey.PublicKey)
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		verifierAddr: {Balance: big.NewInt(0).Mul(big.NewInt(1000), big.NewInt(1e18))},
+	})
+
+	chainID, err := backend.Client().ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting chain id: %w", err)
+	}
+
+	auth, err := bind.NewKeyedTransactorWithChainID(verifierKey, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("creating transactor: %w", err)
+	}
+
+	parsedABI, err := abi.JSON(strings.NewReader(bindings.FilecoinWarmStorageServiceABI))
+	if err != nil {
+		return nil, fmt.Errorf("parsing contract ABI: %w", err)
+	}
+
+	_, _, contract, err := bindings.DeployFilecoinWarmStorageService(auth, backend.Client())
+	if err != nil {
+		return nil, fmt.Errorf("deploying FilecoinWarmStorageService: %w", err)
+	}
+	backend.Commit()
+
+	return &Harness{
+		Backend:      backend,
+		Contract:     contract,
+		ContractABI:  &parsedABI,
+		VerifierKey:  verifierKey,
+		VerifierAuth: auth,
+	}, nil
+}
+
+// Close releases the backend's resources.
+func (h *Harness) Close() error {
+	return h.Backend.Close()
+}
+
+// CallCreateDataSet invokes dataSetCreated with the given extraData, exactly
+// as the PDPVerifier contract would after a create-data-set proof.
+func (h *Harness) CallCreateDataSet(ctx context.Context, dataSetId *big.Int, storageProvider [20]byte, extraData []byte) (*types.Receipt, error) {
+	tx, err := h.Contract.DataSetCreated(h.VerifierAuth, dataSetId, storageProvider, extraData)
+	if err != nil {
+		return nil, fmt.Errorf("calling dataSetCreated: %w", err)
+	}
+	h.Backend.Commit()
+	return bind.WaitMined(ctx, h.Backend.Client(), tx)
+}
@@ -0,0 +1,49 @@
+package simtest
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/storacha/filecoin-services/go/eip712"
+)
+
+// TestDataSetCreatedRoundTrip feeds extraData produced by ExtraDataEncoder
+// straight into the deployed contract's dataSetCreated callback, rather than
+// only re-decoding it with the same Go-side ABI types.
+func TestDataSetCreatedRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	h, err := New(ctx)
+	require.NoError(t, err)
+	defer h.Close()
+
+	payerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	payer := crypto.PubkeyToAddress(payerKey.PublicKey)
+
+	clientDataSetId := big.NewInt(7)
+	metadata := []eip712.MetadataEntry{{Key: "label", Value: "integration"}}
+
+	t.Run("good signature succeeds", func(t *testing.T) {
+		sig := &eip712.AuthSignature{V: 27, R: [32]byte{1}, S: [32]byte{2}, Signer: payer}
+		builder := eip712.NewTypedDataBuilder(eip712.Domain{})
+		signed := &eip712.SignedTypedData{
+			TypedData: builder.BuildCreateDataSet(payer, clientDataSetId, metadata),
+			Signature: sig,
+		}
+
+		encoder := eip712.NewExtraDataEncoder()
+		extraData, err := encoder.EncodeCreateDataSetExtraData(payer, clientDataSetId, metadata, signed)
+		require.NoError(t, err)
+
+		var storageProvider [20]byte
+		copy(storageProvider[:], h.VerifierAuth.From.Bytes())
+
+		_, err = h.CallCreateDataSet(ctx, clientDataSetId, storageProvider, extraData)
+		require.NoError(t, err)
+	})
+}
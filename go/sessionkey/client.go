@@ -0,0 +1,56 @@
+package sessionkey
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Client submits signed LoginRequests to a Relayer's HTTP endpoint, for
+// callers that want to enroll a session key without paying their own gas.
+type Client struct {
+	HTTPClient *http.Client
+}
+
+// NewClient returns a Client with a sensible default timeout. Set
+// HTTPClient directly for anything more specific.
+func NewClient() *Client {
+	return &Client{HTTPClient: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// SubmitToRelayer POSTs req and its signature (as produced by
+// SignLoginRequest) to relayerURL and returns the resulting transaction
+// hash.
+func (c *Client) SubmitToRelayer(ctx context.Context, relayerURL string, req LoginRequest, sig []byte) (string, error) {
+	body, err := json.Marshal(toWireLoginRequest(req, sig))
+	if err != nil {
+		return "", fmt.Errorf("encoding login request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, relayerURL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("building relayer request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("submitting login request to relayer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var relayResp wireRelayResponse
+	if err := json.NewDecoder(resp.Body).Decode(&relayResp); err != nil {
+		return "", fmt.Errorf("decoding relayer response: %w", err)
+	}
+	if relayResp.Error != "" {
+		return "", fmt.Errorf("relayer rejected login request: %s", relayResp.Error)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("relayer returned unexpected status %s", resp.Status)
+	}
+	return relayResp.TransactionHash, nil
+}
@@ -0,0 +1,182 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+	"github.com/storacha/filecoin-services/go/sessionkey"
+)
+
+// Indexer replays a SessionKeyRegistry's AuthorizationsUpdated history into
+// a Store and then follows new events, keeping the Store's view of active
+// (identity, signer, permission) authorizations current. An
+// AuthorizationsUpdated event with Expiry == 0 is treated as a revocation;
+// any other Expiry upserts the permissions it covers.
+type Indexer struct {
+	registry *bindings.SessionKeyRegistry
+	store    Store
+
+	// StartBlock is where replay begins the first time Indexer runs against
+	// a Store with no saved checkpoint yet.
+	StartBlock uint64
+	// PruneInterval controls how often Run drops expired records from the
+	// Store in the background. Zero disables pruning.
+	PruneInterval time.Duration
+}
+
+// NewIndexer returns an Indexer for registry, persisting into store and
+// replaying from startBlock on a Store that has never been checkpointed.
+func NewIndexer(registry *bindings.SessionKeyRegistry, store Store, startBlock uint64) *Indexer {
+	return &Indexer{registry: registry, store: store, StartBlock: startBlock}
+}
+
+// Run replays history from the Store's last checkpoint (or StartBlock, on a
+// fresh Store) and then follows new AuthorizationsUpdated events until ctx
+// is cancelled or the subscription fails.
+func (idx *Indexer) Run(ctx context.Context) error {
+	if err := idx.replay(ctx); err != nil {
+		return fmt.Errorf("replaying session key authorization history: %w", err)
+	}
+
+	if idx.PruneInterval > 0 {
+		go idx.pruneLoop(ctx)
+	}
+
+	return idx.follow(ctx)
+}
+
+// ActiveSigners returns every (signer, permission) grant for identity that
+// is still unexpired as of atTime.
+func (idx *Indexer) ActiveSigners(ctx context.Context, identity common.Address, atTime time.Time) ([]Signer, error) {
+	return idx.store.ActiveSigners(ctx, identity, atTime)
+}
+
+// SignersFor returns the distinct signer addresses authorized for
+// permission on behalf of identity as of atTime.
+func (idx *Indexer) SignersFor(ctx context.Context, identity common.Address, permission sessionkey.Permission, atTime time.Time) ([]common.Address, error) {
+	return idx.store.SignersFor(ctx, identity, permission, atTime)
+}
+
+// OriginsFor returns the distinct origins recorded against identity's
+// still-active authorizations as of atTime.
+func (idx *Indexer) OriginsFor(ctx context.Context, identity common.Address, atTime time.Time) ([]string, error) {
+	return idx.store.OriginsFor(ctx, identity, atTime)
+}
+
+func (idx *Indexer) replay(ctx context.Context) error {
+	fromBlock, ok, err := idx.store.LoadCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fromBlock = idx.StartBlock
+	} else {
+		fromBlock++
+	}
+
+	iter, err := idx.registry.FilterAuthorizationsUpdated(&bind.FilterOpts{Start: fromBlock, Context: ctx}, nil)
+	if err != nil {
+		return fmt.Errorf("filtering AuthorizationsUpdated from block %d: %w", fromBlock, err)
+	}
+	defer iter.Close()
+
+	for iter.Next() {
+		if err := idx.applyEvent(ctx, iter.Event); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+func (idx *Indexer) follow(ctx context.Context) error {
+	sink := make(chan *bindings.SessionKeyRegistryAuthorizationsUpdated, 64)
+	sub, err := idx.registry.WatchAuthorizationsUpdated(&bind.WatchOpts{Context: ctx}, sink, nil)
+	if err != nil {
+		return fmt.Errorf("subscribing to AuthorizationsUpdated: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			return fmt.Errorf("AuthorizationsUpdated subscription: %w", err)
+		case event := <-sink:
+			if event.Raw.Removed {
+				if err := idx.rewind(ctx, event.Raw.BlockNumber); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := idx.applyEvent(ctx, event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// rewind drops every record a reorg has orphaned starting at blockNumber,
+// resets the checkpoint to just before it, and replays forward again - the
+// canonical chain may tell a different story about that block than the one
+// that was just removed.
+func (idx *Indexer) rewind(ctx context.Context, blockNumber uint64) error {
+	if err := idx.store.RemoveFrom(ctx, blockNumber); err != nil {
+		return fmt.Errorf("rewinding session key index from block %d: %w", blockNumber, err)
+	}
+	if blockNumber == 0 {
+		if err := idx.store.SaveCheckpoint(ctx, 0); err != nil {
+			return err
+		}
+		return idx.replay(ctx)
+	}
+	if err := idx.store.SaveCheckpoint(ctx, blockNumber-1); err != nil {
+		return err
+	}
+	return idx.replay(ctx)
+}
+
+func (idx *Indexer) applyEvent(ctx context.Context, ev *bindings.SessionKeyRegistryAuthorizationsUpdated) error {
+	for _, tag := range ev.Permissions {
+		permission := sessionkey.Permission(tag)
+
+		if ev.Expiry.Sign() <= 0 {
+			if err := idx.store.Remove(ctx, ev.Identity, ev.Signer, permission); err != nil {
+				return fmt.Errorf("removing revoked permission %s: %w", permission, err)
+			}
+			continue
+		}
+
+		record := Record{
+			Identity:    ev.Identity,
+			Signer:      ev.Signer,
+			Permission:  permission,
+			Expiry:      time.Unix(ev.Expiry.Int64(), 0),
+			Origin:      ev.Origin,
+			BlockNumber: ev.Raw.BlockNumber,
+			LogIndex:    ev.Raw.Index,
+		}
+		if err := idx.store.Upsert(ctx, record); err != nil {
+			return fmt.Errorf("indexing authorization for permission %s: %w", permission, err)
+		}
+	}
+	return idx.store.SaveCheckpoint(ctx, ev.Raw.BlockNumber)
+}
+
+func (idx *Indexer) pruneLoop(ctx context.Context) {
+	ticker := time.NewTicker(idx.PruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = idx.store.PruneExpired(ctx, time.Now())
+		}
+	}
+}
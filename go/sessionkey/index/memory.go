@@ -0,0 +1,157 @@
+package index
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/storacha/filecoin-services/go/sessionkey"
+)
+
+type memoryKey struct {
+	Identity   common.Address
+	Signer     common.Address
+	Permission sessionkey.Permission
+}
+
+// MemoryStore is an in-process Store backed by a map, for services that
+// don't need the index to survive a restart (or that run Indexer.Run from
+// genesis cheaply enough not to care). Use SQLStore when restarts must
+// resume from a checkpoint instead of re-scanning the chain.
+type MemoryStore struct {
+	mu            sync.RWMutex
+	records       map[memoryKey]Record
+	checkpoint    uint64
+	hasCheckpoint bool
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[memoryKey]Record)}
+}
+
+func (s *MemoryStore) Upsert(ctx context.Context, record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := memoryKey{record.Identity, record.Signer, record.Permission}
+	if existing, ok := s.records[key]; ok && !isNewer(record, existing) {
+		return nil
+	}
+	s.records[key] = record
+	return nil
+}
+
+func (s *MemoryStore) Remove(ctx context.Context, identity, signer common.Address, permission sessionkey.Permission) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, memoryKey{identity, signer, permission})
+	return nil
+}
+
+func (s *MemoryStore) RemoveFrom(ctx context.Context, blockNumber uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, record := range s.records {
+		if record.BlockNumber >= blockNumber {
+			delete(s.records, key)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) ActiveSigners(ctx context.Context, identity common.Address, atTime time.Time) ([]Signer, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []Signer
+	for _, record := range s.records {
+		if record.Identity != identity || !record.Expiry.After(atTime) {
+			continue
+		}
+		out = append(out, Signer{
+			Address:    record.Signer,
+			Permission: record.Permission,
+			Expiry:     record.Expiry,
+			Origin:     record.Origin,
+		})
+	}
+	return out, nil
+}
+
+func (s *MemoryStore) SignersFor(ctx context.Context, identity common.Address, permission sessionkey.Permission, atTime time.Time) ([]common.Address, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[common.Address]bool)
+	var out []common.Address
+	for _, record := range s.records {
+		if record.Identity != identity || record.Permission != permission || !record.Expiry.After(atTime) {
+			continue
+		}
+		if seen[record.Signer] {
+			continue
+		}
+		seen[record.Signer] = true
+		out = append(out, record.Signer)
+	}
+	return out, nil
+}
+
+func (s *MemoryStore) OriginsFor(ctx context.Context, identity common.Address, atTime time.Time) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var out []string
+	for _, record := range s.records {
+		if record.Identity != identity || !record.Expiry.After(atTime) {
+			continue
+		}
+		if seen[record.Origin] {
+			continue
+		}
+		seen[record.Origin] = true
+		out = append(out, record.Origin)
+	}
+	return out, nil
+}
+
+func (s *MemoryStore) PruneExpired(ctx context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pruned := 0
+	for key, record := range s.records {
+		if !record.Expiry.After(before) {
+			delete(s.records, key)
+			pruned++
+		}
+	}
+	return pruned, nil
+}
+
+func (s *MemoryStore) SaveCheckpoint(ctx context.Context, blockNumber uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoint = blockNumber
+	s.hasCheckpoint = true
+	return nil
+}
+
+func (s *MemoryStore) LoadCheckpoint(ctx context.Context) (uint64, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.checkpoint, s.hasCheckpoint, nil
+}
+
+// isNewer reports whether a was observed after b, by (BlockNumber, LogIndex)
+// order.
+func isNewer(a, b Record) bool {
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber > b.BlockNumber
+	}
+	return a.LogIndex > b.LogIndex
+}
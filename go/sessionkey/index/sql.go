@@ -0,0 +1,193 @@
+package index
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/storacha/filecoin-services/go/sessionkey"
+)
+
+// SQLStore is a Store backed by a SQL database via database/sql, for
+// services that need the index to survive a restart without re-scanning
+// the chain from genesis. It targets the SQLite/Postgres upsert dialect
+// ("INSERT ... ON CONFLICT ... DO UPDATE") and '?' placeholders; adapt the
+// queries below if your driver needs something else.
+//
+// Callers own creating the schema SQLStore expects:
+//
+//	CREATE TABLE session_key_authorizations (
+//		identity     TEXT NOT NULL,
+//		signer       TEXT NOT NULL,
+//		permission   TEXT NOT NULL,
+//		expiry       INTEGER NOT NULL,
+//		origin       TEXT NOT NULL,
+//		block_number INTEGER NOT NULL,
+//		log_index    INTEGER NOT NULL,
+//		PRIMARY KEY (identity, signer, permission)
+//	);
+//	CREATE TABLE session_key_checkpoint (
+//		id           INTEGER PRIMARY KEY CHECK (id = 0),
+//		block_number INTEGER NOT NULL
+//	);
+type SQLStore struct {
+	db *sql.DB
+}
+
+// NewSQLStore returns a Store that reads and writes through db, which must
+// already have the schema documented on SQLStore applied.
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+func (s *SQLStore) Upsert(ctx context.Context, record Record) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO session_key_authorizations (identity, signer, permission, expiry, origin, block_number, log_index)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (identity, signer, permission) DO UPDATE SET
+			expiry = excluded.expiry,
+			origin = excluded.origin,
+			block_number = excluded.block_number,
+			log_index = excluded.log_index
+		WHERE excluded.block_number > session_key_authorizations.block_number
+		   OR (excluded.block_number = session_key_authorizations.block_number AND excluded.log_index > session_key_authorizations.log_index)
+	`,
+		record.Identity.Hex(), record.Signer.Hex(), record.Permission.String(),
+		record.Expiry.Unix(), record.Origin, record.BlockNumber, record.LogIndex,
+	)
+	if err != nil {
+		return fmt.Errorf("upserting session key authorization: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) Remove(ctx context.Context, identity, signer common.Address, permission sessionkey.Permission) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM session_key_authorizations WHERE identity = ? AND signer = ? AND permission = ?`,
+		identity.Hex(), signer.Hex(), permission.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("removing session key authorization: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) RemoveFrom(ctx context.Context, blockNumber uint64) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM session_key_authorizations WHERE block_number >= ?`, blockNumber,
+	)
+	if err != nil {
+		return fmt.Errorf("rewinding session key authorizations from block %d: %w", blockNumber, err)
+	}
+	return nil
+}
+
+func (s *SQLStore) ActiveSigners(ctx context.Context, identity common.Address, atTime time.Time) ([]Signer, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT signer, permission, expiry, origin FROM session_key_authorizations WHERE identity = ? AND expiry > ?`,
+		identity.Hex(), atTime.Unix(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying active signers for %s: %w", identity.Hex(), err)
+	}
+	defer rows.Close()
+
+	var out []Signer
+	for rows.Next() {
+		var signerHex, permissionHex, origin string
+		var expiryUnix int64
+		if err := rows.Scan(&signerHex, &permissionHex, &expiryUnix, &origin); err != nil {
+			return nil, fmt.Errorf("scanning active signer row: %w", err)
+		}
+		out = append(out, Signer{
+			Address:    common.HexToAddress(signerHex),
+			Permission: sessionkey.Permission(common.HexToHash(permissionHex)),
+			Expiry:     time.Unix(expiryUnix, 0),
+			Origin:     origin,
+		})
+	}
+	return out, rows.Err()
+}
+
+func (s *SQLStore) SignersFor(ctx context.Context, identity common.Address, permission sessionkey.Permission, atTime time.Time) ([]common.Address, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT DISTINCT signer FROM session_key_authorizations WHERE identity = ? AND permission = ? AND expiry > ?`,
+		identity.Hex(), permission.String(), atTime.Unix(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying signers for permission %s: %w", permission, err)
+	}
+	defer rows.Close()
+
+	var out []common.Address
+	for rows.Next() {
+		var signerHex string
+		if err := rows.Scan(&signerHex); err != nil {
+			return nil, fmt.Errorf("scanning signer row: %w", err)
+		}
+		out = append(out, common.HexToAddress(signerHex))
+	}
+	return out, rows.Err()
+}
+
+func (s *SQLStore) OriginsFor(ctx context.Context, identity common.Address, atTime time.Time) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT DISTINCT origin FROM session_key_authorizations WHERE identity = ? AND expiry > ?`,
+		identity.Hex(), atTime.Unix(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying origins for %s: %w", identity.Hex(), err)
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var origin string
+		if err := rows.Scan(&origin); err != nil {
+			return nil, fmt.Errorf("scanning origin row: %w", err)
+		}
+		out = append(out, origin)
+	}
+	return out, rows.Err()
+}
+
+func (s *SQLStore) PruneExpired(ctx context.Context, before time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx,
+		`DELETE FROM session_key_authorizations WHERE expiry <= ?`, before.Unix(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("pruning expired session key authorizations: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("counting pruned session key authorizations: %w", err)
+	}
+	return int(affected), nil
+}
+
+func (s *SQLStore) SaveCheckpoint(ctx context.Context, blockNumber uint64) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO session_key_checkpoint (id, block_number) VALUES (0, ?)
+		ON CONFLICT (id) DO UPDATE SET block_number = excluded.block_number
+	`, blockNumber)
+	if err != nil {
+		return fmt.Errorf("saving session key index checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) LoadCheckpoint(ctx context.Context) (uint64, bool, error) {
+	var blockNumber uint64
+	err := s.db.QueryRowContext(ctx, `SELECT block_number FROM session_key_checkpoint WHERE id = 0`).Scan(&blockNumber)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("loading session key index checkpoint: %w", err)
+	}
+	return blockNumber, true, nil
+}
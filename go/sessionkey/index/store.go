@@ -0,0 +1,80 @@
+// Package index maintains a queryable local view of SessionKeyRegistry
+// authorizations, built by replaying and then following its
+// AuthorizationsUpdated event, so a service can answer "is this signer
+// authorized for X right now" against local state instead of an RPC
+// round-trip (or the per-tuple AuthorizationExpiry call sessionkey.Manager
+// makes) on every check.
+package index
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/storacha/filecoin-services/go/sessionkey"
+)
+
+// Record is one (identity, signer, permission) authorization as last
+// observed on chain. BlockNumber and LogIndex identify exactly which log
+// produced it, so a Store can tell a stale replayed event from a newer one
+// and rewind cleanly when a reorg removes a log.
+type Record struct {
+	Identity    common.Address
+	Signer      common.Address
+	Permission  sessionkey.Permission
+	Expiry      time.Time
+	Origin      string
+	BlockNumber uint64
+	LogIndex    uint
+}
+
+// Signer is one active authorization returned by ActiveSigners: a signer
+// address, the single permission it covers, and when that grant expires.
+// An identity with a signer authorized for several permissions shows up as
+// several Signer entries, one per permission.
+type Signer struct {
+	Address    common.Address
+	Permission sessionkey.Permission
+	Expiry     time.Time
+	Origin     string
+}
+
+// Store is the pluggable persistence layer behind Indexer. Implementations
+// must make Upsert idempotent against out-of-order delivery: a Record with
+// an older (BlockNumber, LogIndex) than what's already stored for the same
+// (identity, signer, permission) must be ignored, not applied.
+type Store interface {
+	// Upsert records identity/signer/permission as authorized until
+	// record.Expiry, unless a newer record for the same tuple is already
+	// stored.
+	Upsert(ctx context.Context, record Record) error
+	// Remove drops the (identity, signer, permission) tuple entirely, used
+	// when a Revoke event is observed.
+	Remove(ctx context.Context, identity, signer common.Address, permission sessionkey.Permission) error
+	// RemoveFrom drops every record at or after blockNumber, used to rewind
+	// state a reorg has invalidated.
+	RemoveFrom(ctx context.Context, blockNumber uint64) error
+
+	// ActiveSigners returns every (signer, permission) grant for identity
+	// that is still unexpired as of atTime.
+	ActiveSigners(ctx context.Context, identity common.Address, atTime time.Time) ([]Signer, error)
+	// SignersFor returns the distinct signer addresses authorized for
+	// permission on behalf of identity as of atTime.
+	SignersFor(ctx context.Context, identity common.Address, permission sessionkey.Permission, atTime time.Time) ([]common.Address, error)
+	// OriginsFor returns the distinct origins recorded against identity's
+	// still-active authorizations as of atTime.
+	OriginsFor(ctx context.Context, identity common.Address, atTime time.Time) ([]string, error)
+
+	// PruneExpired deletes every record whose expiry is at or before
+	// before, and reports how many were removed.
+	PruneExpired(ctx context.Context, before time.Time) (int, error)
+
+	// SaveCheckpoint records the last block number this Store has fully
+	// processed, so a restart can resume from there instead of replaying
+	// from genesis.
+	SaveCheckpoint(ctx context.Context, blockNumber uint64) error
+	// LoadCheckpoint returns the last saved checkpoint, or ok=false if
+	// none has been saved yet.
+	LoadCheckpoint(ctx context.Context) (blockNumber uint64, ok bool, err error)
+}
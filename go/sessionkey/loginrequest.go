@@ -0,0 +1,144 @@
+package sessionkey
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/storacha/filecoin-services/go/eip712"
+)
+
+// LoginRequest is the off-chain counterpart of an on-chain login() call: a
+// user signs one of these instead of submitting a transaction, so a Relayer
+// can submit it on their behalf via loginBySig and the user never needs FIL
+// in their own wallet to start a session.
+type LoginRequest struct {
+	// Identity is the user authorizing Signer (the contract's msg.sender
+	// equivalent for a direct login() call).
+	Identity common.Address
+	// Signer is the session key being granted Permissions.
+	Signer common.Address
+	// Expiry is when the granted authorization itself lapses.
+	Expiry time.Time
+	// Permissions is the set of capabilities being granted to Signer.
+	Permissions []Permission
+	// Origin is the human-readable origin string the contract records
+	// alongside the authorization.
+	Origin string
+	// Nonce is this identity's replay-protection nonce. Callers must use a
+	// value the relayer hasn't already seen for Identity.
+	Nonce *big.Int
+	// Deadline is when this signed request itself expires and a relayer
+	// must refuse to submit it, independent of Expiry.
+	Deadline time.Time
+}
+
+// loginRequestType is the "LoginRequest" EIP-712 type signed here, mirroring
+// the arguments the (not-yet-deployed) loginBySig contract entry point
+// takes: identity, signer, expiry, permissions[], origin, nonce, deadline.
+var loginRequestType = []apitypes.Type{
+	{Name: "identity", Type: "address"},
+	{Name: "signer", Type: "address"},
+	{Name: "expiry", Type: "uint256"},
+	{Name: "permissions", Type: "bytes32[]"},
+	{Name: "origin", Type: "string"},
+	{Name: "nonce", Type: "uint256"},
+	{Name: "deadline", Type: "uint256"},
+}
+
+func buildLoginRequestTypedData(domain eip712.Domain, req LoginRequest) (apitypes.TypedData, error) {
+	permissions := make([]string, len(req.Permissions))
+	for i, permission := range req.Permissions {
+		permissions[i] = permission.String()
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"LoginRequest": loginRequestType,
+		},
+		PrimaryType: "LoginRequest",
+		Domain: apitypes.TypedDataDomain{
+			Name:              domain.Name,
+			Version:           domain.Version,
+			ChainId:           (*math.HexOrDecimal256)(domain.ChainID),
+			VerifyingContract: domain.VerifyingContract.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"identity":    req.Identity.Hex(),
+			"signer":      req.Signer.Hex(),
+			"expiry":      big.NewInt(req.Expiry.Unix()).String(),
+			"permissions": permissions,
+			"origin":      req.Origin,
+			"nonce":       req.Nonce.String(),
+			"deadline":    big.NewInt(req.Deadline.Unix()).String(),
+		},
+	}, nil
+}
+
+// SignLoginRequest signs req under domain with privKey and returns the
+// resulting 65-byte [R || S || V] signature (V normalized into {27,28}, the
+// range the contract's ECDSA recovery expects), ready to hand to
+// Client.SubmitToRelayer.
+func SignLoginRequest(privKey *ecdsa.PrivateKey, domain eip712.Domain, req LoginRequest) ([]byte, error) {
+	typedData, err := buildLoginRequestTypedData(domain, req)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := eip712.Digest(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("hashing login request: %w", err)
+	}
+
+	sig, err := crypto.Sign(digest[:], privKey)
+	if err != nil {
+		return nil, fmt.Errorf("signing login request: %w", err)
+	}
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	return sig, nil
+}
+
+// verifyLoginRequestSignature reports whether sig is a valid signature of
+// req under domain, produced by req.Identity's private key.
+func verifyLoginRequestSignature(domain eip712.Domain, req LoginRequest, sig []byte) (bool, error) {
+	if len(sig) != 65 {
+		return false, fmt.Errorf("signature must be 65 bytes, got %d", len(sig))
+	}
+
+	typedData, err := buildLoginRequestTypedData(domain, req)
+	if err != nil {
+		return false, err
+	}
+
+	digest, err := eip712.Digest(typedData)
+	if err != nil {
+		return false, fmt.Errorf("hashing login request: %w", err)
+	}
+
+	recoverable := make([]byte, 65)
+	copy(recoverable, sig)
+	if recoverable[64] >= 27 {
+		recoverable[64] -= 27
+	}
+
+	pubKey, err := crypto.SigToPub(digest[:], recoverable)
+	if err != nil {
+		return false, fmt.Errorf("recovering signer from signature: %w", err)
+	}
+
+	return crypto.PubkeyToAddress(*pubKey) == req.Identity, nil
+}
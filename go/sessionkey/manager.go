@@ -0,0 +1,225 @@
+// Package sessionkey wraps the generated bindings.SessionKeyRegistry binding
+// with a typed Permission catalog, an expiry cache, and renewal/revocation
+// helpers, so a service no longer has to hand-compute permission tags, poll
+// authorizationExpiry before every privileged call, or re-derive its own
+// Login/Revoke bookkeeping.
+package sessionkey
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+)
+
+// cacheKey identifies one (user, signer, permission) authorization tuple in
+// Manager's expiry cache.
+type cacheKey struct {
+	User       common.Address
+	Signer     common.Address
+	Permission Permission
+}
+
+// Manager is a high-level wrapper around one deployed SessionKeyRegistry: it
+// caches authorizationExpiry lookups, keeps that cache fresh by watching
+// AuthorizationsUpdated, and knows how to renew or revoke a signer's
+// permissions without the caller touching the raw ABI.
+type Manager struct {
+	registry *bindings.SessionKeyRegistry
+
+	mu    sync.RWMutex
+	cache map[cacheKey]time.Time
+}
+
+// New binds a Manager to the SessionKeyRegistry deployed at address, using
+// backend for both calls and transactions (an *ethclient.Client satisfies
+// this, as does a simulated backend in tests).
+func New(address common.Address, backend bind.ContractBackend) (*Manager, error) {
+	registry, err := bindings.NewSessionKeyRegistry(address, backend)
+	if err != nil {
+		return nil, fmt.Errorf("binding SessionKeyRegistry at %s: %w", address.Hex(), err)
+	}
+	return &Manager{
+		registry: registry,
+		cache:    make(map[cacheKey]time.Time),
+	}, nil
+}
+
+// AuthorizationExpiry returns when signer's authorization for permission on
+// behalf of user expires, serving from cache when the pair has already been
+// looked up (or set by EnsureAuthorized/Watch) and falling back to the
+// contract's authorizationExpiry view otherwise. A zero time.Time means the
+// permission has never been authorized.
+func (m *Manager) AuthorizationExpiry(ctx context.Context, user, signer common.Address, permission Permission) (time.Time, error) {
+	key := cacheKey{User: user, Signer: signer, Permission: permission}
+
+	m.mu.RLock()
+	expiry, ok := m.cache[key]
+	m.mu.RUnlock()
+	if ok {
+		return expiry, nil
+	}
+
+	raw, err := m.registry.AuthorizationExpiry(&bind.CallOpts{Context: ctx}, user, signer, permission.Bytes32())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("querying authorizationExpiry for permission %s: %w", permission, err)
+	}
+	expiry = time.Unix(raw.Int64(), 0)
+
+	m.mu.Lock()
+	m.cache[key] = expiry
+	m.mu.Unlock()
+
+	return expiry, nil
+}
+
+// Watch subscribes to AuthorizationsUpdated and drops this Manager's cached
+// expiries for whichever (user, signer) pair just changed, so the next
+// AuthorizationExpiry call re-queries the contract instead of serving a
+// stale value. It runs until ctx is cancelled; callers that want to stop
+// watching should cancel the context they pass in here.
+func (m *Manager) Watch(ctx context.Context) error {
+	sink := make(chan *bindings.SessionKeyRegistryAuthorizationsUpdated, 32)
+	sub, err := m.registry.WatchAuthorizationsUpdated(&bind.WatchOpts{Context: ctx}, sink, nil)
+	if err != nil {
+		return fmt.Errorf("subscribing to AuthorizationsUpdated: %w", err)
+	}
+
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sub.Err():
+				return
+			case update := <-sink:
+				m.invalidate(update.Identity, update.Signer)
+			}
+		}
+	}()
+	return nil
+}
+
+func (m *Manager) invalidate(user, signer common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.cache {
+		if key.User == user && key.Signer == signer {
+			delete(m.cache, key)
+		}
+	}
+}
+
+// LoginParams configures the Login/LoginAndFund call EnsureAuthorized issues
+// when a permission is missing or close to expiring.
+type LoginParams struct {
+	// Expiry is the new authorization's absolute expiry time.
+	Expiry time.Time
+	// Origin is the human-readable origin string the contract records
+	// alongside the authorization, e.g. the calling service's name.
+	Origin string
+	// FundWei, when set and positive, issues LoginAndFund instead of Login,
+	// sending FundWei to the signer address as part of the transaction.
+	FundWei *big.Int
+}
+
+// EnsureAuthorized checks auth.From's expiry for every permission in
+// permissions and, if any has less than minRemaining left (including a
+// permission that was never authorized at all), re-issues Login - or
+// LoginAndFund, when params.FundWei is set - covering the full permissions
+// list so they all share params.Expiry going forward. It returns nil, nil
+// (no transaction) when every permission already clears minRemaining.
+func (m *Manager) EnsureAuthorized(ctx context.Context, auth *bind.TransactOpts, signer common.Address, permissions []Permission, minRemaining time.Duration, params LoginParams) (*types.Transaction, error) {
+	user := auth.From
+
+	needsRenewal := false
+	for _, permission := range permissions {
+		expiry, err := m.AuthorizationExpiry(ctx, user, signer, permission)
+		if err != nil {
+			return nil, err
+		}
+		if time.Until(expiry) < minRemaining {
+			needsRenewal = true
+			break
+		}
+	}
+	if !needsRenewal {
+		return nil, nil
+	}
+
+	tags := make([][32]byte, len(permissions))
+	for i, permission := range permissions {
+		tags[i] = permission.Bytes32()
+	}
+	expiry := big.NewInt(params.Expiry.Unix())
+
+	var tx *types.Transaction
+	var err error
+	if params.FundWei != nil && params.FundWei.Sign() > 0 {
+		fundedAuth := *auth
+		fundedAuth.Value = params.FundWei
+		tx, err = m.registry.LoginAndFund(&fundedAuth, signer, expiry, tags, params.Origin)
+	} else {
+		tx, err = m.registry.Login(auth, signer, expiry, tags, params.Origin)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("renewing session key authorization: %w", err)
+	}
+
+	m.mu.Lock()
+	for _, permission := range permissions {
+		m.cache[cacheKey{User: user, Signer: signer, Permission: permission}] = params.Expiry
+	}
+	m.mu.Unlock()
+
+	return tx, nil
+}
+
+// RevokeAll submits one Revoke transaction covering every permission this
+// Manager currently has cached for (auth.From, signer). Permissions the
+// cache hasn't seen yet - because neither AuthorizationExpiry nor
+// EnsureAuthorized has been called for them - aren't included; callers that
+// need an exhaustive revoke should query the permissions they care about
+// first so the cache actually knows about them.
+func (m *Manager) RevokeAll(ctx context.Context, auth *bind.TransactOpts, signer common.Address, origin string) (*types.Transaction, error) {
+	user := auth.From
+
+	m.mu.RLock()
+	var permissions []Permission
+	for key := range m.cache {
+		if key.User == user && key.Signer == signer {
+			permissions = append(permissions, key.Permission)
+		}
+	}
+	m.mu.RUnlock()
+
+	if len(permissions) == 0 {
+		return nil, fmt.Errorf("no cached permissions for signer %s under user %s; call AuthorizationExpiry for the permissions to revoke first", signer.Hex(), user.Hex())
+	}
+
+	tags := make([][32]byte, len(permissions))
+	for i, permission := range permissions {
+		tags[i] = permission.Bytes32()
+	}
+
+	tx, err := m.registry.Revoke(auth, signer, tags, origin)
+	if err != nil {
+		return nil, fmt.Errorf("revoking session key permissions: %w", err)
+	}
+
+	m.mu.Lock()
+	for _, permission := range permissions {
+		delete(m.cache, cacheKey{User: user, Signer: signer, Permission: permission})
+	}
+	m.mu.Unlock()
+
+	return tx, nil
+}
@@ -0,0 +1,52 @@
+package sessionkey
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Permission identifies one capability a session key can be authorized for
+// in the SessionKeyRegistry contract. The contract itself only knows about
+// opaque bytes32 tags; Permission and NewPermission exist so callers work in
+// terms of a service and action instead of hand-computing
+// keccak256("service:action") (or worse, copy-pasting someone else's
+// pre-computed [32]byte) at every call site.
+type Permission [32]byte
+
+// NewPermission derives the Permission tag for a service/action pair, e.g.
+// NewPermission("pdp", "proofset:create"). Prefer one of the well-known
+// Perm* constants below when the capability is already cataloged there.
+func NewPermission(service, action string) Permission {
+	return Permission(crypto.Keccak256Hash([]byte(service + ":" + action)))
+}
+
+// Bytes32 returns p in the [32]byte form the generated SessionKeyRegistry
+// binding's methods expect.
+func (p Permission) Bytes32() [32]byte {
+	return [32]byte(p)
+}
+
+// String returns p's hex encoding, for logging and error messages.
+func (p Permission) String() string {
+	return common.Hash(p).Hex()
+}
+
+// Well-known permissions for the services that currently authorize session
+// keys against SessionKeyRegistry. Add new capabilities here, rather than
+// calling NewPermission inline at a call site, so the full catalog of what a
+// session key can be authorized for lives in one place.
+var (
+	// PermPayments authorizes a session key to act on the payer's behalf
+	// across the Payments contract (deposits, approvals, settlement).
+	PermPayments = NewPermission("payments", "*")
+
+	// PermPDPProofSetCreate authorizes creating a new PDP proof set.
+	PermPDPProofSetCreate = NewPermission("pdp", "proofset:create")
+	// PermPDPProofSetDelete authorizes deleting a PDP proof set.
+	PermPDPProofSetDelete = NewPermission("pdp", "proofset:delete")
+	// PermPDPPiecesAdd authorizes adding pieces to a PDP proof set.
+	PermPDPPiecesAdd = NewPermission("pdp", "pieces:add")
+	// PermPDPPiecesRemove authorizes scheduling piece removals from a PDP
+	// proof set.
+	PermPDPPiecesRemove = NewPermission("pdp", "pieces:remove")
+)
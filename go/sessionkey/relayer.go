@@ -0,0 +1,281 @@
+package sessionkey
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/storacha/filecoin-services/go/eip712"
+)
+
+// loginBySigABI is the minimal ABI fragment for SessionKeyRegistry's
+// loginBySig entry point, parsed once at init so a Relayer can encode the
+// call without a generated binding. loginBySig does not exist in the
+// deployed contract yet; this plumbing is ready for it to land alongside
+// the Solidity change it requires.
+var loginBySigABI abi.ABI
+
+func init() {
+	const loginBySigJSON = `[{
+		"name": "loginBySig",
+		"type": "function",
+		"stateMutability": "nonpayable",
+		"inputs": [
+			{"name": "identity", "type": "address"},
+			{"name": "signer", "type": "address"},
+			{"name": "expiry", "type": "uint256"},
+			{"name": "permissions", "type": "bytes32[]"},
+			{"name": "origin", "type": "string"},
+			{"name": "nonce", "type": "uint256"},
+			{"name": "deadline", "type": "uint256"},
+			{"name": "v", "type": "uint8"},
+			{"name": "r", "type": "bytes32"},
+			{"name": "s", "type": "bytes32"}
+		],
+		"outputs": []
+	}]`
+
+	parsed, err := abi.JSON(strings.NewReader(loginBySigJSON))
+	if err != nil {
+		panic(fmt.Sprintf("parsing loginBySig ABI: %v", err))
+	}
+	loginBySigABI = parsed
+}
+
+// AuthFunc produces a fresh *bind.TransactOpts for the relayer's own
+// account - the one paying gas on behalf of the identities submitting
+// LoginRequests - each time the Relayer needs to submit a loginBySig
+// transaction.
+type AuthFunc func(ctx context.Context) (*bind.TransactOpts, error)
+
+// Relayer accepts signed LoginRequests over HTTP and submits them to
+// SessionKeyRegistry.loginBySig on the signer's behalf, so a user can start
+// a session without holding FIL to pay gas themselves. It is deliberately
+// unopinionated about who may use it: set Allow (and RateLimit) to avoid
+// running an open faucet.
+type Relayer struct {
+	registryAddress common.Address
+	backend         bind.ContractBackend
+	domain          eip712.Domain
+	authFunc        AuthFunc
+
+	// Allow, when set, is consulted for every request before it is
+	// submitted; it should return false for identities the operator hasn't
+	// cleared to use this relayer. A nil Allow accepts every identity.
+	Allow func(identity common.Address) bool
+
+	// RateLimit caps how many requests a single identity may submit within
+	// RateLimitWindow. RateLimit <= 0 disables rate limiting.
+	RateLimit       int
+	RateLimitWindow time.Duration
+
+	noncesMu   sync.Mutex
+	seenNonces map[common.Address]map[string]bool
+	requestsMu sync.Mutex
+	requestLog map[common.Address][]time.Time
+}
+
+// NewRelayer returns a Relayer for the SessionKeyRegistry at registryAddress
+// under domain, submitting transactions via backend and signing them with
+// whatever *bind.TransactOpts authFunc returns.
+func NewRelayer(registryAddress common.Address, backend bind.ContractBackend, domain eip712.Domain, authFunc AuthFunc) *Relayer {
+	return &Relayer{
+		registryAddress: registryAddress,
+		backend:         backend,
+		domain:          domain,
+		authFunc:        authFunc,
+		seenNonces:      make(map[common.Address]map[string]bool),
+		requestLog:      make(map[common.Address][]time.Time),
+	}
+}
+
+// ServeHTTP implements http.Handler: it decodes a signed LoginRequest, runs
+// it through the allowlist, rate limit, replay, deadline, and signature
+// checks, then submits loginBySig. The response body is always a
+// wireRelayResponse; a non-2xx status additionally indicates the request
+// was rejected.
+func (rl *Relayer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var wire wireLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&wire); err != nil {
+		respondRelayError(w, http.StatusBadRequest, fmt.Errorf("decoding request: %w", err))
+		return
+	}
+
+	req, sig, err := wire.toLoginRequest()
+	if err != nil {
+		respondRelayError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	txHash, err := rl.Relay(r.Context(), req, sig)
+	if err != nil {
+		respondRelayError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, wireRelayResponse{TransactionHash: txHash})
+}
+
+// Relay validates a signed LoginRequest and, if it passes every check,
+// submits loginBySig on the identity's behalf, returning the resulting
+// transaction hash.
+//
+// The nonce is only checked (not burned) before signature verification,
+// since req.Identity is unauthenticated request data up to that point - an
+// attacker naming a victim's identity with a garbage signature must not be
+// able to burn a nonce on the victim's behalf. Once the signature is valid,
+// the nonce is reserved atomically with that check via reserveNonce, closing
+// the window between checking and recording that let two concurrent Relay
+// calls for the same (identity, nonce) both pass and double-submit
+// loginBySig. If authFunc or submitLoginBySig then fails, releaseNonce frees
+// the reservation so the caller can retry with the same nonce.
+func (rl *Relayer) Relay(ctx context.Context, req LoginRequest, sig []byte) (string, error) {
+	if rl.Allow != nil && !rl.Allow(req.Identity) {
+		return "", fmt.Errorf("identity %s is not allowed to use this relayer", req.Identity.Hex())
+	}
+	if err := rl.checkRateLimit(req.Identity); err != nil {
+		return "", err
+	}
+	if time.Now().After(req.Deadline) {
+		return "", fmt.Errorf("login request deadline %s has passed", req.Deadline)
+	}
+
+	ok, err := verifyLoginRequestSignature(rl.domain, req, sig)
+	if err != nil {
+		return "", fmt.Errorf("verifying login request signature: %w", err)
+	}
+	if !ok {
+		return "", fmt.Errorf("signature does not match identity %s", req.Identity.Hex())
+	}
+
+	if err := rl.reserveNonce(req.Identity, req.Nonce); err != nil {
+		return "", err
+	}
+
+	auth, err := rl.authFunc(ctx)
+	if err != nil {
+		rl.releaseNonce(req.Identity, req.Nonce)
+		return "", fmt.Errorf("preparing relayer transaction signer: %w", err)
+	}
+
+	tx, err := rl.submitLoginBySig(auth, req, sig)
+	if err != nil {
+		rl.releaseNonce(req.Identity, req.Nonce)
+		return "", fmt.Errorf("submitting loginBySig: %w", err)
+	}
+	return tx.Hash().Hex(), nil
+}
+
+func (rl *Relayer) submitLoginBySig(auth *bind.TransactOpts, req LoginRequest, sig []byte) (*types.Transaction, error) {
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("signature must be 65 bytes, got %d", len(sig))
+	}
+	var r, s [32]byte
+	copy(r[:], sig[0:32])
+	copy(s[:], sig[32:64])
+	v := sig[64]
+
+	tags := make([][32]byte, len(req.Permissions))
+	for i, permission := range req.Permissions {
+		tags[i] = permission.Bytes32()
+	}
+
+	contract := bind.NewBoundContract(rl.registryAddress, loginBySigABI, rl.backend, rl.backend, rl.backend)
+	return contract.Transact(auth, "loginBySig",
+		req.Identity,
+		req.Signer,
+		big.NewInt(req.Expiry.Unix()),
+		tags,
+		req.Origin,
+		req.Nonce,
+		big.NewInt(req.Deadline.Unix()),
+		v,
+		r,
+		s,
+	)
+}
+
+// reserveNonce checks and records nonce as used by identity in a single lock
+// acquisition, reporting an error if it was already reserved. This is the
+// only place seenNonces is written to as "used" before a submission
+// succeeds, so two concurrent callers can never both observe the nonce as
+// free.
+func (rl *Relayer) reserveNonce(identity common.Address, nonce *big.Int) error {
+	rl.noncesMu.Lock()
+	defer rl.noncesMu.Unlock()
+
+	if rl.seenNonces[identity][nonce.String()] {
+		return fmt.Errorf("nonce %s has already been used by identity %s", nonce.String(), identity.Hex())
+	}
+
+	used, ok := rl.seenNonces[identity]
+	if !ok {
+		used = make(map[string]bool)
+		rl.seenNonces[identity] = used
+	}
+	used[nonce.String()] = true
+	return nil
+}
+
+// releaseNonce undoes a reserveNonce whose guarded submission never
+// succeeded, freeing the nonce for a retry instead of permanently gapping
+// it.
+func (rl *Relayer) releaseNonce(identity common.Address, nonce *big.Int) {
+	rl.noncesMu.Lock()
+	defer rl.noncesMu.Unlock()
+
+	delete(rl.seenNonces[identity], nonce.String())
+}
+
+func (rl *Relayer) checkRateLimit(identity common.Address) error {
+	if rl.RateLimit <= 0 {
+		return nil
+	}
+
+	rl.requestsMu.Lock()
+	defer rl.requestsMu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-rl.RateLimitWindow)
+
+	history := rl.requestLog[identity]
+	kept := history[:0]
+	for _, t := range history {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+
+	if len(kept) >= rl.RateLimit {
+		rl.requestLog[identity] = kept
+		return fmt.Errorf("identity %s exceeded %d requests per %s", identity.Hex(), rl.RateLimit, rl.RateLimitWindow)
+	}
+
+	rl.requestLog[identity] = append(kept, now)
+	return nil
+}
+
+func respondRelayError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, wireRelayResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,40 @@
+package sktest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+)
+
+// ExpectEvent drains every AuthorizationsUpdated log recorded for identity
+// (or every identity, if identity is the zero address) and returns the
+// first one filter accepts, failing t if none match. It's meant to be
+// called after the action under test has already been committed, since it
+// reads historical logs rather than subscribing live.
+func ExpectEvent(t *testing.T, h *Harness, identity common.Address, filter func(*bindings.SessionKeyRegistryAuthorizationsUpdated) bool) *bindings.SessionKeyRegistryAuthorizationsUpdated {
+	t.Helper()
+
+	var identityFilter []common.Address
+	if identity != (common.Address{}) {
+		identityFilter = []common.Address{identity}
+	}
+
+	iter, err := h.Contract.FilterAuthorizationsUpdated(&bind.FilterOpts{Context: context.Background()}, identityFilter)
+	require.NoError(t, err)
+	defer iter.Close()
+
+	for iter.Next() {
+		if filter(iter.Event) {
+			return iter.Event
+		}
+	}
+	require.NoError(t, iter.Error())
+
+	t.Fatalf("no AuthorizationsUpdated event for identity %s matched the filter", identity.Hex())
+	return nil
+}
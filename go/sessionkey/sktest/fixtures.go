@@ -0,0 +1,45 @@
+package sktest
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/storacha/filecoin-services/go/sessionkey"
+)
+
+// DeterministicKey derives a *ecdsa.PrivateKey from seed: the same seed
+// always yields the same key, so tests can refer to "the identity" or "the
+// signer" by name (DeterministicKey("identity-1")) instead of generating
+// and threading through a random key every run.
+func DeterministicKey(seed string) (*ecdsa.PrivateKey, error) {
+	digest := sha256.Sum256([]byte(seed))
+	key, err := crypto.ToECDSA(digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("deriving deterministic key from seed %q: %w", seed, err)
+	}
+	return key, nil
+}
+
+// MustDeterministicKey is DeterministicKey for callers (test fixtures) that
+// would otherwise immediately require.NoError the error away; it panics on
+// failure instead, since a bad seed is a programming error, not something a
+// test should assert on.
+func MustDeterministicKey(seed string) *ecdsa.PrivateKey {
+	key, err := DeterministicKey(seed)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// Canonical test permissions, distinct from the production catalog in
+// package sessionkey, for tests that want permissions clearly scoped to
+// sktest fixtures rather than real capabilities.
+var (
+	TestPermissionAlpha = sessionkey.NewPermission("sktest", "alpha")
+	TestPermissionBeta  = sessionkey.NewPermission("sktest", "beta")
+	TestPermissionGamma = sessionkey.NewPermission("sktest", "gamma")
+)
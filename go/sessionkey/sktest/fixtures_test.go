@@ -0,0 +1,30 @@
+package sktest
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeterministicKeyIsStableAndDistinct(t *testing.T) {
+	key1a, err := DeterministicKey("identity-1")
+	require.NoError(t, err)
+	key1b, err := DeterministicKey("identity-1")
+	require.NoError(t, err)
+	key2, err := DeterministicKey("identity-2")
+	require.NoError(t, err)
+
+	addr1a := crypto.PubkeyToAddress(key1a.PublicKey)
+	addr1b := crypto.PubkeyToAddress(key1b.PublicKey)
+	addr2 := crypto.PubkeyToAddress(key2.PublicKey)
+
+	require.Equal(t, addr1a, addr1b, "same seed must derive the same key")
+	require.NotEqual(t, addr1a, addr2, "different seeds must derive different keys")
+}
+
+func TestCanonicalTestPermissionsAreDistinct(t *testing.T) {
+	require.NotEqual(t, TestPermissionAlpha, TestPermissionBeta)
+	require.NotEqual(t, TestPermissionBeta, TestPermissionGamma)
+	require.NotEqual(t, TestPermissionAlpha, TestPermissionGamma)
+}
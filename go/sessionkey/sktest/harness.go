@@ -0,0 +1,153 @@
+// Package sktest provides a simulated-backend test harness for
+// SessionKeyRegistry, so packages that consume sessionkey (payments, PDP)
+// can exercise login/revoke/expiry flows in unit tests without an RPC
+// endpoint.
+//
+// This module only carries SessionKeyRegistry's ABI (go/bindings has no Bin
+// for it, so bind.DeployContract has nothing to deploy on its own) - New
+// takes the contract's creation bytecode as a parameter, which callers get
+// from wherever SessionKeyRegistry.sol is compiled in this repo.
+package sktest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+	"github.com/storacha/filecoin-services/go/sessionkey"
+)
+
+// Harness wires up a simulated chain with a deployed SessionKeyRegistry and
+// a funded admin account that pays gas for every helper method below.
+type Harness struct {
+	Backend   *simulated.Backend
+	Contract  *bindings.SessionKeyRegistry
+	Address   common.Address
+	AdminKey  *ecdsa.PrivateKey
+	AdminAuth *bind.TransactOpts
+}
+
+// New deploys bytecode - SessionKeyRegistry's compiled creation bytecode -
+// on a fresh simulated backend funded with a single admin account, and
+// returns a Harness wrapping the result.
+func New(ctx context.Context, bytecode []byte) (*Harness, error) {
+	adminKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("generating admin key: %w", err)
+	}
+	adminAddr := crypto.PubkeyToAddress(adminKey.PublicKey)
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		adminAddr: {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+	})
+
+	chainID, err := backend.Client().ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting chain id: %w", err)
+	}
+
+	auth, err := bind.NewKeyedTransactorWithChainID(adminKey, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("creating transactor: %w", err)
+	}
+
+	parsedABI, err := abi.JSON(strings.NewReader(bindings.SessionKeyRegistryABI))
+	if err != nil {
+		return nil, fmt.Errorf("parsing SessionKeyRegistry ABI: %w", err)
+	}
+
+	address, _, _, err := bind.DeployContract(auth, parsedABI, bytecode, backend.Client())
+	if err != nil {
+		return nil, fmt.Errorf("deploying SessionKeyRegistry: %w", err)
+	}
+	backend.Commit()
+
+	contract, err := bindings.NewSessionKeyRegistry(address, backend.Client())
+	if err != nil {
+		return nil, fmt.Errorf("binding deployed SessionKeyRegistry: %w", err)
+	}
+
+	return &Harness{
+		Backend:   backend,
+		Contract:  contract,
+		Address:   address,
+		AdminKey:  adminKey,
+		AdminAuth: auth,
+	}, nil
+}
+
+// Close releases the backend's resources.
+func (h *Harness) Close() error {
+	return h.Backend.Close()
+}
+
+// Fund sends wei from the admin account to addr.
+func (h *Harness) Fund(ctx context.Context, addr common.Address, wei *big.Int) error {
+	client := h.Backend.Client()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("getting chain id: %w", err)
+	}
+	nonce, err := client.PendingNonceAt(ctx, h.AdminAuth.From)
+	if err != nil {
+		return fmt.Errorf("getting admin nonce: %w", err)
+	}
+
+	tx := types.NewTransaction(nonce, addr, wei, 21000, big.NewInt(1e9), nil)
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(chainID), h.AdminKey)
+	if err != nil {
+		return fmt.Errorf("signing funding transaction: %w", err)
+	}
+	if err := client.SendTransaction(ctx, signed); err != nil {
+		return fmt.Errorf("sending funding transaction: %w", err)
+	}
+	h.Backend.Commit()
+	return nil
+}
+
+// LoginAs submits SessionKeyRegistry.login as identity, authorizing signer
+// for permissions until expiry.
+func (h *Harness) LoginAs(ctx context.Context, identityKey *ecdsa.PrivateKey, signer common.Address, permissions []sessionkey.Permission, expiry time.Time) (*types.Transaction, error) {
+	chainID, err := h.Backend.Client().ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting chain id: %w", err)
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(identityKey, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("creating identity transactor: %w", err)
+	}
+
+	tags := make([][32]byte, len(permissions))
+	for i, permission := range permissions {
+		tags[i] = permission.Bytes32()
+	}
+
+	tx, err := h.Contract.Login(auth, signer, big.NewInt(expiry.Unix()), tags, "sktest")
+	if err != nil {
+		return nil, fmt.Errorf("calling login: %w", err)
+	}
+	h.Backend.Commit()
+	return tx, nil
+}
+
+// AdvanceTime moves the simulated chain's clock forward by dur, so tests can
+// exercise expiry without sleeping in real time.
+func (h *Harness) AdvanceTime(dur time.Duration) error {
+	if err := h.Backend.AdjustTime(dur); err != nil {
+		return fmt.Errorf("advancing simulated time by %s: %w", dur, err)
+	}
+	h.Backend.Commit()
+	return nil
+}
@@ -0,0 +1,78 @@
+package sessionkey
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// wireLoginRequest is the JSON form of a signed LoginRequest exchanged
+// between Client and Relayer. Every numeric/binary field is a string to
+// avoid precision loss and the ambiguity of encoding raw bytes as a JSON
+// array.
+type wireLoginRequest struct {
+	Identity    common.Address `json:"identity"`
+	Signer      common.Address `json:"signer"`
+	Expiry      int64          `json:"expiry"`
+	Permissions []string       `json:"permissions"`
+	Origin      string         `json:"origin"`
+	Nonce       string         `json:"nonce"`
+	Deadline    int64          `json:"deadline"`
+	Signature   string         `json:"signature"`
+}
+
+// wireRelayResponse is the JSON response a Relayer sends back: exactly one
+// of TransactionHash or Error is set.
+type wireRelayResponse struct {
+	TransactionHash string `json:"transactionHash,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+func toWireLoginRequest(req LoginRequest, sig []byte) wireLoginRequest {
+	permissions := make([]string, len(req.Permissions))
+	for i, permission := range req.Permissions {
+		permissions[i] = permission.String()
+	}
+	return wireLoginRequest{
+		Identity:    req.Identity,
+		Signer:      req.Signer,
+		Expiry:      req.Expiry.Unix(),
+		Permissions: permissions,
+		Origin:      req.Origin,
+		Nonce:       req.Nonce.String(),
+		Deadline:    req.Deadline.Unix(),
+		Signature:   hex.EncodeToString(sig),
+	}
+}
+
+func (w wireLoginRequest) toLoginRequest() (LoginRequest, []byte, error) {
+	permissions := make([]Permission, len(w.Permissions))
+	for i, hexPermission := range w.Permissions {
+		hash := common.HexToHash(hexPermission)
+		permissions[i] = Permission(hash)
+	}
+
+	nonce, ok := new(big.Int).SetString(w.Nonce, 10)
+	if !ok {
+		return LoginRequest{}, nil, fmt.Errorf("invalid nonce %q: not a base-10 integer", w.Nonce)
+	}
+
+	sig, err := hex.DecodeString(w.Signature)
+	if err != nil {
+		return LoginRequest{}, nil, fmt.Errorf("invalid signature encoding: %w", err)
+	}
+
+	req := LoginRequest{
+		Identity:    w.Identity,
+		Signer:      w.Signer,
+		Expiry:      time.Unix(w.Expiry, 0),
+		Permissions: permissions,
+		Origin:      w.Origin,
+		Nonce:       nonce,
+		Deadline:    time.Unix(w.Deadline, 0),
+	}
+	return req, sig, nil
+}
@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/network"
+)
+
+var (
+	initNetwork       string
+	initChainID       uint64
+	initOutDir        string
+	initPasswordFile  string
+	initOwnerKeystore string
+	initPayerKeystore string
+	initFaucet        bool
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Bootstrap a service-operator.yaml for a network",
+	Long: `Bootstrap a working service-operator.yaml: generate or import owner/payer keystores, fill in
+the canonical contract addresses for --network, probe the RPC endpoint to sanity-check it's
+actually that network, and write the result to --out-dir/service-operator.yaml.
+
+Contract addresses come from a bundled per-network registry; any address the registry doesn't
+know yet must be passed explicitly via the matching --*-contract-address flag.`,
+	RunE: runInit,
+}
+
+func init() {
+	initCmd.Flags().StringVar(&initNetwork, "network", "", "Network to bootstrap for: calibration or mainnet")
+	initCmd.Flags().Uint64Var(&initChainID, "chain-id", 0, "Chain ID to use instead of --network, for a custom deployment (requires passing every --*-contract-address explicitly)")
+	initCmd.Flags().StringVar(&initOutDir, "out-dir", ".", "Directory to write service-operator.yaml and generated keystores into")
+	initCmd.Flags().StringVar(&initPasswordFile, "password-file", "", "Path to a file containing the keystore password (prompted interactively if omitted)")
+	initCmd.Flags().StringVar(&initOwnerKeystore, "owner-keystore", "", "Path to an existing owner keystore to import, instead of generating a new one")
+	initCmd.Flags().StringVar(&initPayerKeystore, "payer-keystore", "", "Path to an existing payer keystore to import, instead of generating a new one")
+	initCmd.Flags().BoolVar(&initFaucet, "faucet", false, "Print faucet instructions for requesting test USDFC (calibration only)")
+
+	rootCmd.AddCommand(initCmd)
+}
+
+func runInit(cobraCmd *cobra.Command, args []string) error {
+	ctx := cobraCmd.Context()
+
+	reg, rpcURL, err := resolveNetwork()
+	if err != nil {
+		return err
+	}
+
+	addrs, err := resolveContractAddresses(reg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(initOutDir, 0o755); err != nil {
+		return fmt.Errorf("creating --out-dir %s: %w", initOutDir, err)
+	}
+
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	if err := probeChain(ctx, client, reg.ChainID, addrs); err != nil {
+		return err
+	}
+
+	password, err := resolveKeystorePassword()
+	if err != nil {
+		return err
+	}
+
+	keystoreDir := filepath.Join(initOutDir, "keystores")
+	ownerPath, err := resolveRoleKeystore(keystoreDir, "owner", initOwnerKeystore, password)
+	if err != nil {
+		return err
+	}
+	payerPath, err := resolveRoleKeystore(keystoreDir, "payer", initPayerKeystore, password)
+	if err != nil {
+		return err
+	}
+
+	configPath := filepath.Join(initOutDir, "service-operator.yaml")
+	if err := writeConfigFile(configPath, rpcURL, addrs, ownerPath, payerPath, password); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Wrote %s\n", configPath)
+	fmt.Printf("  Owner keystore: %s\n", ownerPath)
+	fmt.Printf("  Payer keystore: %s\n", payerPath)
+
+	if initFaucet {
+		printFaucetInstructions(reg)
+	}
+
+	return nil
+}
+
+// resolveNetwork returns the bundled Registry (when --network is set) or a
+// bare Registry carrying just --chain-id (when it isn't), along with the
+// RPC URL to probe: the --rpc-url override if given, else the registry's
+// default.
+func resolveNetwork() (network.Registry, string, error) {
+	rpcURLOverride := viper.GetString("rpc_url")
+
+	if initNetwork != "" {
+		reg, err := network.Lookup(initNetwork)
+		if err != nil {
+			return network.Registry{}, "", err
+		}
+		rpcURL := reg.DefaultRPCUrl
+		if rpcURLOverride != "" {
+			rpcURL = rpcURLOverride
+		}
+		return reg, rpcURL, nil
+	}
+
+	if initChainID == 0 {
+		return network.Registry{}, "", fmt.Errorf("either --network or --chain-id is required")
+	}
+	if rpcURLOverride == "" {
+		return network.Registry{}, "", fmt.Errorf("--rpc-url is required when using --chain-id instead of --network")
+	}
+	return network.Registry{Name: "custom", ChainID: initChainID}, rpcURLOverride, nil
+}
+
+// contractAddresses holds the five addresses init writes into
+// service-operator.yaml.
+type contractAddresses struct {
+	Service         string
+	Verifier        string
+	ServiceRegistry string
+	Payments        string
+	Token           string
+}
+
+// resolveContractAddresses fills each address from the matching persistent
+// --*-contract-address flag when set, falling back to reg; it errors out
+// listing exactly which flags are still needed when neither has one.
+func resolveContractAddresses(reg network.Registry) (contractAddresses, error) {
+	pick := func(flagKey, fromRegistry string) string {
+		if v := viper.GetString(flagKey); v != "" {
+			return v
+		}
+		return fromRegistry
+	}
+
+	addrs := contractAddresses{
+		Service:         pick("service_contract_address", reg.ServiceContractAddress),
+		Verifier:        pick("verifier_contract_address", reg.VerifierContractAddress),
+		ServiceRegistry: pick("service_registry_contract_address", reg.ServiceRegistryContractAddress),
+		Payments:        pick("payments_contract_address", reg.PaymentsContractAddress),
+		Token:           pick("token_contract_address", reg.TokenContractAddress),
+	}
+
+	var missing []string
+	if addrs.Service == "" {
+		missing = append(missing, "--service-contract-address")
+	}
+	if addrs.Verifier == "" {
+		missing = append(missing, "--verifier-contract-address")
+	}
+	if addrs.ServiceRegistry == "" {
+		missing = append(missing, "--service-registry-contract-address")
+	}
+	if addrs.Payments == "" {
+		missing = append(missing, "--payments-contract-address")
+	}
+	if addrs.Token == "" {
+		missing = append(missing, "--token-contract-address")
+	}
+	if len(missing) > 0 {
+		return contractAddresses{}, fmt.Errorf("network %q doesn't have a bundled address for: %s (pass them explicitly)", reg.Name, strings.Join(missing, ", "))
+	}
+
+	for flagName, addr := range map[string]string{
+		"--service-contract-address":          addrs.Service,
+		"--verifier-contract-address":         addrs.Verifier,
+		"--service-registry-contract-address": addrs.ServiceRegistry,
+		"--payments-contract-address":         addrs.Payments,
+		"--token-contract-address":            addrs.Token,
+	} {
+		if !common.IsHexAddress(addr) {
+			return contractAddresses{}, fmt.Errorf("invalid %s: %s", flagName, addr)
+		}
+	}
+
+	return addrs, nil
+}
+
+// probeChain sanity-checks rpcURL actually serves the network init thinks
+// it's bootstrapping for, by comparing the live chain ID and confirming
+// every contract address has deployed bytecode - a wrong network or a typo
+// in an address flag fails loudly here instead of silently producing a
+// config that can't do anything.
+func probeChain(ctx context.Context, client *ethclient.Client, wantChainID uint64, addrs contractAddresses) error {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("querying chain ID: %w", err)
+	}
+	if wantChainID != 0 && chainID.Uint64() != wantChainID {
+		return fmt.Errorf("RPC endpoint reports chain ID %s, expected %d", chainID.String(), wantChainID)
+	}
+
+	for label, addr := range map[string]string{
+		"service contract":          addrs.Service,
+		"verifier contract":         addrs.Verifier,
+		"service registry contract": addrs.ServiceRegistry,
+		"payments contract":         addrs.Payments,
+		"token contract":            addrs.Token,
+	} {
+		code, err := client.CodeAt(ctx, common.HexToAddress(addr), nil)
+		if err != nil {
+			return fmt.Errorf("checking for %s code at %s: %w", label, addr, err)
+		}
+		if len(code) == 0 {
+			return fmt.Errorf("no contract code found at %s (%s) - wrong network or address?", addr, label)
+		}
+	}
+
+	return nil
+}
+
+func resolveKeystorePassword() (string, error) {
+	if initPasswordFile != "" {
+		data, err := os.ReadFile(initPasswordFile)
+		if err != nil {
+			return "", fmt.Errorf("reading --password-file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	fmt.Print("Keystore password: ")
+	passwordBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("reading password: %w", err)
+	}
+
+	fmt.Print("Confirm password: ")
+	confirmBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("reading password confirmation: %w", err)
+	}
+
+	if string(passwordBytes) != string(confirmBytes) {
+		return "", fmt.Errorf("passwords did not match")
+	}
+	return string(passwordBytes), nil
+}
+
+// resolveRoleKeystore imports an existing keystore (validating it decrypts
+// with password) when importPath is set, or generates a new one under dir,
+// returning the path to use in service-operator.yaml either way.
+func resolveRoleKeystore(dir, role, importPath, password string) (string, error) {
+	if importPath != "" {
+		data, err := os.ReadFile(importPath)
+		if err != nil {
+			return "", fmt.Errorf("reading --%s-keystore: %w", role, err)
+		}
+		if _, err := keystore.DecryptKey(data, password); err != nil {
+			return "", fmt.Errorf("decrypting --%s-keystore with the given password: %w", role, err)
+		}
+		return importPath, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("creating keystore directory %s: %w", dir, err)
+	}
+
+	ks := keystore.NewKeyStore(dir, keystore.StandardScryptN, keystore.StandardScryptP)
+	account, err := ks.NewAccount(password)
+	if err != nil {
+		return "", fmt.Errorf("generating %s keystore: %w", role, err)
+	}
+	return account.URL.Path, nil
+}
+
+const configFileTemplate = `rpc_url: %q
+
+service_contract_address: %q
+verifier_contract_address: %q
+service_registry_contract_address: %q
+payments_contract_address: %q
+token_contract_address: %q
+
+signers:
+  owner:
+    keystore: %q
+    keystore_password: %q
+  payer:
+    keystore: %q
+    keystore_password: %q
+`
+
+func writeConfigFile(path, rpcURL string, addrs contractAddresses, ownerKeystore, payerKeystore, password string) error {
+	contents := fmt.Sprintf(configFileTemplate,
+		rpcURL,
+		addrs.Service,
+		addrs.Verifier,
+		addrs.ServiceRegistry,
+		addrs.Payments,
+		addrs.Token,
+		ownerKeystore,
+		password,
+		payerKeystore,
+		password,
+	)
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+func printFaucetInstructions(reg network.Registry) {
+	fmt.Println()
+	if reg.FaucetURL == "" {
+		fmt.Printf("Network %q has no known faucet; fund the owner/payer addresses manually.\n", reg.Name)
+		return
+	}
+	fmt.Printf("Request test USDFC for your owner and payer addresses at:\n  %s\n", reg.FaucetURL)
+}
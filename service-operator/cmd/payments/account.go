@@ -12,11 +12,27 @@ import (
 	"github.com/storacha/filecoin-services/go/bindings"
 	"github.com/storacha/filecoin-services/service-operator/internal/config"
 	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
 	paymentsutil "github.com/storacha/filecoin-services/service-operator/internal/payments"
 )
 
+// AccountBalanceResult is the --output=json payload for `payments account`.
+// Big-int fields marshal as decimal strings, matching go-ethereum's
+// core/types JSON conventions.
+type AccountBalanceResult struct {
+	Token              common.Address `json:"token"`
+	Account            common.Address `json:"account"`
+	Funds              string         `json:"funds"`
+	FundsFormatted     string         `json:"fundsFormatted"`
+	Locked             string         `json:"locked"`
+	LockedFormatted    string         `json:"lockedFormatted"`
+	Available          string         `json:"available"`
+	AvailableFormatted string         `json:"availableFormatted"`
+}
+
 var (
 	accountAddress string
+	accountIndex   uint32
 )
 
 var accountCmd = &cobra.Command{
@@ -43,6 +59,7 @@ Examples:
 
 func init() {
 	accountCmd.Flags().StringVar(&accountAddress, "address", "", "Address to check (defaults to keystore address if not specified)")
+	accountCmd.Flags().Uint32Var(&accountIndex, "account-index", 0, "HD account index to derive the payer address from (requires signers.payer.mnemonic or seed_file)")
 }
 
 func runAccount(cobraCmd *cobra.Command, args []string) error {
@@ -70,7 +87,7 @@ func runAccount(cobraCmd *cobra.Command, args []string) error {
 	} else {
 		// No address specified - use payer signer address
 		signerManager := contract.NewSignerManager(cfg)
-		privateKey, err := signerManager.LoadPayerSigner()
+		privateKey, err := signerManager.LoadPayerSignerAtIndex(accountIndex)
 		if err != nil {
 			return fmt.Errorf("loading payer signer: %w", err)
 		}
@@ -97,6 +114,22 @@ func runAccount(cobraCmd *cobra.Command, args []string) error {
 	// Calculate available funds
 	availableFunds := new(big.Int).Sub(accountInfo.Funds, accountInfo.LockupCurrent)
 
+	result := AccountBalanceResult{
+		Token:              cfg.TokenAddr(),
+		Account:            queryAddr,
+		Funds:              accountInfo.Funds.String(),
+		FundsFormatted:     paymentsutil.FormatTokenAmount(accountInfo.Funds, decimals),
+		Locked:             accountInfo.LockupCurrent.String(),
+		LockedFormatted:    paymentsutil.FormatTokenAmount(accountInfo.LockupCurrent, decimals),
+		Available:          availableFunds.String(),
+		AvailableFormatted: paymentsutil.FormatTokenAmount(availableFunds, decimals),
+	}
+	if emitted, err := output.Emit(result); err != nil {
+		return err
+	} else if emitted {
+		return nil
+	}
+
 	// Display results
 	fmt.Println("Payments Account Balance")
 	fmt.Println("========================")
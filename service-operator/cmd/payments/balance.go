@@ -3,15 +3,27 @@ package payments
 import (
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/spf13/cobra"
 
 	"github.com/storacha/filecoin-services/service-operator/internal/config"
 	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
 	paymentsutil "github.com/storacha/filecoin-services/service-operator/internal/payments"
 )
 
+var balanceAccountIndex uint32
+
+// WalletBalanceResult is the --output=json payload for `payments balance`.
+type WalletBalanceResult struct {
+	Token            common.Address `json:"token"`
+	Account          common.Address `json:"account"`
+	Balance          string         `json:"balance"`
+	BalanceFormatted string         `json:"balanceFormatted"`
+}
+
 var balanceCmd = &cobra.Command{
 	Use:   "balance",
 	Short: "Display USDFC token balance in your wallet",
@@ -33,6 +45,10 @@ Examples:
 	RunE: runBalance,
 }
 
+func init() {
+	balanceCmd.Flags().Uint32Var(&balanceAccountIndex, "account-index", 0, "HD account index to derive the payer address from (requires signers.payer.mnemonic or seed_file)")
+}
+
 func runBalance(cobraCmd *cobra.Command, args []string) error {
 	ctx := cobraCmd.Context()
 
@@ -49,7 +65,7 @@ func runBalance(cobraCmd *cobra.Command, args []string) error {
 
 	// Create signer manager and load payer's private key to get address
 	signerManager := contract.NewSignerManager(cfg)
-	privateKey, err := signerManager.LoadPayerSigner()
+	privateKey, err := signerManager.LoadPayerSignerAtIndex(balanceAccountIndex)
 	if err != nil {
 		return fmt.Errorf("loading payer signer: %w", err)
 	}
@@ -68,6 +84,18 @@ func runBalance(cobraCmd *cobra.Command, args []string) error {
 		return fmt.Errorf("querying token balance: %w", err)
 	}
 
+	result := WalletBalanceResult{
+		Token:            cfg.TokenAddr(),
+		Account:          ownerAddr,
+		Balance:          balance.String(),
+		BalanceFormatted: paymentsutil.FormatTokenAmount(balance, decimals),
+	}
+	if emitted, err := output.Emit(result); err != nil {
+		return err
+	} else if emitted {
+		return nil
+	}
+
 	// Display results
 	fmt.Println("USDFC Wallet Balance")
 	fmt.Println("====================")
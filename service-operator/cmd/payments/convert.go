@@ -10,13 +10,33 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/storacha/filecoin-services/service-operator/internal/config"
 	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
 	"github.com/storacha/filecoin-services/service-operator/internal/payments"
+	"github.com/storacha/filecoin-services/service-operator/internal/price"
 )
 
+// ConvertResult is the --output=json payload for `payments convert`.
+type ConvertResult struct {
+	DollarAmount       float64 `json:"dollarAmount"`
+	TokenDecimals      uint8   `json:"tokenDecimals"`
+	DecimalsSource     string  `json:"decimalsSource"`
+	PriceSource        string  `json:"priceSource"`
+	USDPerToken        string  `json:"usdPerToken"`
+	PriceRoundID       string  `json:"priceRoundId,omitempty"`
+	PriceObservedAt    string  `json:"priceObservedAt"`
+	BaseUnits          string  `json:"baseUnits"`
+	BaseUnitsFormatted string  `json:"baseUnitsFormatted"`
+}
+
 var (
 	convertAmount        string
 	convertOutputFormat  string
 	convertTokenDecimals int
+	convertPriceSource   string
+	convertPrice         float64
+	convertChainlinkFeed string
+	convertMaxPriceAge   time.Duration
+	convertCoinGeckoID   string
 )
 
 var convertCmd = &cobra.Command{
@@ -33,13 +53,24 @@ For tokens with 18 decimals (standard ERC20):
 For tokens with 6 decimals (like USDC):
   $1.00 = 1,000,000 base units
 
+By default the token is assumed to be worth exactly $1. For tokens that
+float against the dollar, use --price-source to look up the real exchange
+rate instead:
+  fixed:     --price-source fixed --price 0.98   (a literal USD-per-token override)
+  chainlink: --price-source chainlink --chainlink-feed 0xAggregatorAddress
+  coingecko: --price-source coingecko --coingecko-id filecoin
+
 Examples:
-  # Convert $10 to base units
+  # Convert $10 to base units (token assumed to be $1)
   service-operator payments convert --amount 10
 
   # Convert $10.50 using explicit decimals
   service-operator payments convert --amount 10.50 --token-decimals 18
 
+  # Convert using a live Chainlink price feed, rejecting a stale round
+  service-operator payments convert --amount 10 --price-source chainlink \
+    --chainlink-feed 0x1b44F3514812d835EB1BDB0acB33d3fA3351Ee43 --max-price-age 1h
+
   # Output in shell format for scripting
   service-operator payments convert --amount 10 --format shell
 
@@ -52,10 +83,39 @@ func init() {
 	convertCmd.Flags().StringVar(&convertAmount, "amount", "", "Dollar amount to convert (e.g., 10, $10, 10.50) (required)")
 	convertCmd.Flags().StringVar(&convertOutputFormat, "format", "human", "Output format: human, shell, or direct")
 	convertCmd.Flags().IntVar(&convertTokenDecimals, "token-decimals", -1, "Token decimals (optional, overrides contract query)")
+	convertCmd.Flags().StringVar(&convertPriceSource, "price-source", "fixed", "Where to read the USD-per-token price from: fixed, chainlink, or coingecko")
+	convertCmd.Flags().Float64Var(&convertPrice, "price", 1.0, "USD value of one whole token, used when --price-source=fixed")
+	convertCmd.Flags().StringVar(&convertChainlinkFeed, "chainlink-feed", "", "Address of the Chainlink AggregatorV3Interface feed, required when --price-source=chainlink")
+	convertCmd.Flags().DurationVar(&convertMaxPriceAge, "max-price-age", time.Hour, "Reject a chainlink price whose round is older than this")
+	convertCmd.Flags().StringVar(&convertCoinGeckoID, "coingecko-id", "", "CoinGecko coin id to price, required when --price-source=coingecko")
 
 	cobra.MarkFlagRequired(convertCmd.Flags(), "amount")
 }
 
+// loadPriceSource builds the price.Source named by --price-source, validating
+// the flags it depends on.
+func loadPriceSource(cfg *config.Config) (price.Source, error) {
+	switch convertPriceSource {
+	case "", "fixed":
+		return price.NewFixedSource(convertPrice)
+	case "chainlink":
+		if convertChainlinkFeed == "" {
+			return nil, fmt.Errorf("--chainlink-feed is required when --price-source=chainlink")
+		}
+		if !common.IsHexAddress(convertChainlinkFeed) {
+			return nil, fmt.Errorf("invalid --chainlink-feed address: %s", convertChainlinkFeed)
+		}
+		if cfg.RPCUrl == "" {
+			return nil, fmt.Errorf("--rpc-url is required when --price-source=chainlink")
+		}
+		return price.NewChainlinkSource(cfg.RPCUrl, common.HexToAddress(convertChainlinkFeed), convertMaxPriceAge), nil
+	case "coingecko":
+		return price.NewCoinGeckoSource(convertCoinGeckoID), nil
+	default:
+		return nil, fmt.Errorf("unknown --price-source: %s (supported: fixed, chainlink, coingecko)", convertPriceSource)
+	}
+}
+
 func runConvert(cobraCmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -112,16 +172,42 @@ func runConvert(cobraCmd *cobra.Command, args []string) error {
 		tokenDecimals = decimals
 	}
 
-	// Convert dollars to base units
-	baseUnits, err := payments.DollarToBaseUnits(dollars, tokenDecimals)
+	// Resolve the USD-per-token price and convert dollars to base units
+	priceSource, err := loadPriceSource(cfg)
+	if err != nil {
+		return err
+	}
+	tokenPrice, err := priceSource.FetchPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching token price: %w", err)
+	}
+
+	baseUnits, err := price.ConvertDollarsToBaseUnits(dollars, tokenPrice.USDPerToken, tokenDecimals)
 	if err != nil {
 		return fmt.Errorf("converting to base units: %w", err)
 	}
 
+	result := ConvertResult{
+		DollarAmount:       dollars,
+		TokenDecimals:      tokenDecimals,
+		DecimalsSource:     decimalsSource,
+		PriceSource:        tokenPrice.Source,
+		USDPerToken:        tokenPrice.USDPerToken.Text('f', 8),
+		PriceRoundID:       tokenPrice.RoundID,
+		PriceObservedAt:    tokenPrice.ObservedAt.UTC().Format(time.RFC3339),
+		BaseUnits:          baseUnits.String(),
+		BaseUnitsFormatted: payments.FormatTokenAmount(baseUnits, tokenDecimals),
+	}
+	if emitted, err := output.Emit(result); err != nil {
+		return err
+	} else if emitted {
+		return nil
+	}
+
 	// Output based on format
 	switch convertOutputFormat {
 	case "human":
-		printHumanConvertFormat(dollars, baseUnits, tokenDecimals, decimalsSource)
+		printHumanConvertFormat(dollars, baseUnits, tokenDecimals, decimalsSource, tokenPrice)
 	case "shell":
 		printShellConvertFormat(baseUnits)
 	case "direct":
@@ -133,12 +219,17 @@ func runConvert(cobraCmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func printHumanConvertFormat(dollars float64, baseUnits *big.Int, decimals uint8, source string) {
+func printHumanConvertFormat(dollars float64, baseUnits *big.Int, decimals uint8, source string, tokenPrice *price.Price) {
 	fmt.Println("Dollar to Base Units Conversion")
 	fmt.Println("================================")
 	fmt.Println()
 	fmt.Printf("Input:              $%.2f\n", dollars)
 	fmt.Printf("Token decimals:     %d (%s)\n", decimals, source)
+	fmt.Printf("Price:              $%s per token (source: %s)\n", tokenPrice.USDPerToken.Text('f', 8), tokenPrice.Source)
+	if tokenPrice.RoundID != "" {
+		fmt.Printf("Price round:        %s\n", tokenPrice.RoundID)
+	}
+	fmt.Printf("Price observed at:  %s\n", tokenPrice.ObservedAt.UTC().Format(time.RFC3339))
 	fmt.Printf("Base units:         %s\n", baseUnits.String())
 	fmt.Println()
 	fmt.Println("Usage with deposit:")
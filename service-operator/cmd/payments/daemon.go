@@ -0,0 +1,135 @@
+package payments
+
+import (
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/daemon"
+)
+
+var (
+	daemonInterval           time.Duration
+	daemonMinUnsettledEpochs uint64
+	daemonMinAmount          string
+	daemonAccountIndex       uint32
+	daemonMetricsAddr        string
+	daemonLogFormat          string
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run a long-lived process that settles rails automatically",
+	Long: `Run a long-lived process that watches the Payments contract for rail
+lifecycle events and settles this service provider's rails automatically,
+instead of requiring an operator to run 'payments settle' on a schedule.
+
+Rails are settled once they accumulate at least --min-unsettled-epochs since
+their last settlement, or once the projected settlement amount would be at
+least --min-amount, whichever comes first. A dropped RPC connection or event
+subscription is retried with exponential backoff rather than exiting.
+
+Examples:
+  # Settle rails every 30 minutes once they have 2 hours of unsettled epochs
+  service-operator payments daemon --interval 30m --min-unsettled-epochs 7200
+
+  # Also expose Prometheus metrics and emit structured JSON logs
+  service-operator payments daemon --metrics-addr :9090 --log-format json`,
+	RunE: runDaemon,
+}
+
+func init() {
+	daemonCmd.Flags().DurationVar(&daemonInterval, "interval", 5*time.Minute, "How often to check for rails due for settlement")
+	daemonCmd.Flags().Uint64Var(&daemonMinUnsettledEpochs, "min-unsettled-epochs", 2880, "Minimum epochs since last settlement before a rail is settled again")
+	daemonCmd.Flags().StringVar(&daemonMinAmount, "min-amount", "", "Minimum projected settlement amount (in token base units) that triggers an early settlement")
+	daemonCmd.Flags().Uint32Var(&daemonAccountIndex, "account-index", 0, "HD account index to derive the payer signer from (requires signers.payer.mnemonic or seed_file)")
+	daemonCmd.Flags().StringVar(&daemonMetricsAddr, "metrics-addr", "", "Address to serve Prometheus metrics on, e.g. :9090 (disabled if empty)")
+	daemonCmd.Flags().StringVar(&daemonLogFormat, "log-format", "text", "Log format: text or json")
+}
+
+func runDaemon(cobraCmd *cobra.Command, args []string) error {
+	ctx, cancel := signal.NotifyContext(cobraCmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	logger, err := newLogger(daemonLogFormat)
+	if err != nil {
+		return err
+	}
+
+	client, err := ethclient.DialContext(ctx, cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+
+	signerManager := contract.NewSignerManager(cfg)
+	txSigner, err := signerManager.LoadPayerTxSignerAtIndex(ctx, daemonAccountIndex)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("loading payer signer: %w", err)
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("getting chain ID: %w", err)
+	}
+	client.Close()
+
+	var minAmount *big.Int
+	if daemonMinAmount != "" {
+		minAmount = new(big.Int)
+		if _, ok := minAmount.SetString(daemonMinAmount, 10); !ok {
+			return fmt.Errorf("invalid --min-amount: %s", daemonMinAmount)
+		}
+	}
+
+	d := daemon.New(daemon.Config{
+		RPCUrl:             cfg.RPCUrl,
+		PaymentsAddress:    cfg.PaymentsAddr(),
+		TokenAddress:       cfg.TokenAddr(),
+		ServiceProvider:    txSigner.Address(),
+		TxSigner:           txSigner,
+		ChainID:            chainID,
+		Interval:           daemonInterval,
+		MinUnsettledEpochs: new(big.Int).SetUint64(daemonMinUnsettledEpochs),
+		MinAmount:          minAmount,
+		MetricsAddr:        daemonMetricsAddr,
+		Logger:             logger,
+	})
+
+	logger.Info("starting settlement daemon",
+		"service_provider", txSigner.Address().Hex(),
+		"interval", daemonInterval.String(),
+		"min_unsettled_epochs", daemonMinUnsettledEpochs,
+	)
+
+	return d.Run(ctx)
+}
+
+// newLogger builds the slog.Logger the daemon uses for its entire run,
+// honoring --log-format so it can emit structured JSON under systemd or
+// Kubernetes instead of human-oriented text.
+func newLogger(format string) (*slog.Logger, error) {
+	switch format {
+	case "", "text":
+		return slog.New(slog.NewTextHandler(os.Stderr, nil)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, nil)), nil
+	default:
+		return nil, fmt.Errorf("invalid --log-format %q: must be \"text\" or \"json\"", format)
+	}
+}
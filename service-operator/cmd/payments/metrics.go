@@ -0,0 +1,231 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+)
+
+var (
+	metricsListenAddr          string
+	metricsInterval            time.Duration
+	metricsAccountIndex        uint32
+	metricsProviderPageSize    int64
+	metricsProviderConcurrency int
+	metricsProviderCachePath   string
+	metricsProviderCacheTTL    time.Duration
+)
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Serve payments status as Prometheus metrics",
+	Long: `Run a long-lived process that polls 'payments status' on a fixed interval and
+serves the result as Prometheus gauges on --listen, for dashboards and alerting that
+want the same account/rail data status prints without scraping its text or JSON output.
+
+Examples:
+  # Serve metrics on :9091, refreshing every minute
+  service-operator payments metrics --listen :9091
+
+  # Refresh less often for a quieter RPC endpoint
+  service-operator payments metrics --listen :9091 --interval 5m`,
+	RunE: runMetrics,
+}
+
+func init() {
+	metricsCmd.Flags().StringVar(&metricsListenAddr, "listen", ":9091", "Address to serve Prometheus metrics on")
+	metricsCmd.Flags().DurationVar(&metricsInterval, "interval", time.Minute, "How often to refresh the exported status")
+	metricsCmd.Flags().Uint32Var(&metricsAccountIndex, "account-index", 0, "HD account index to derive the payer address from (requires signers.payer.mnemonic or seed_file)")
+	metricsCmd.Flags().Int64Var(&metricsProviderPageSize, "provider-page-size", 0, "Provider IDs to request per registry page (0 uses the package default)")
+	metricsCmd.Flags().IntVar(&metricsProviderConcurrency, "provider-concurrency", 0, "Max concurrent provider-detail and rail lookups (0 uses the package default)")
+	metricsCmd.Flags().StringVar(&metricsProviderCachePath, "provider-cache", "", "Path to an on-disk cache of provider name/payee metadata (disabled if unset)")
+	metricsCmd.Flags().DurationVar(&metricsProviderCacheTTL, "provider-cache-ttl", 10*time.Minute, "How long a cached provider entry stays valid")
+}
+
+// paymentsMetrics holds the Prometheus series `payments metrics` exposes on
+// /metrics, refreshed from a CollectStatus snapshot.
+type paymentsMetrics struct {
+	registry *prometheus.Registry
+
+	funds            prometheus.Gauge
+	lockedFunds      prometheus.Gauge
+	availableFunds   prometheus.Gauge
+	rateAllowance    prometheus.Gauge
+	rateUsage        prometheus.Gauge
+	lockupAllowance  prometheus.Gauge
+	lockupUsage      prometheus.Gauge
+	operatorApproved prometheus.Gauge
+
+	railPaymentRate  *prometheus.GaugeVec
+	railSettledEpoch *prometheus.GaugeVec
+}
+
+func newPaymentsMetrics() *paymentsMetrics {
+	registry := prometheus.NewRegistry()
+
+	m := &paymentsMetrics{
+		registry: registry,
+		funds: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "payments_account_funds_base_units",
+			Help: "Total funds deposited by the payer account in the Payments contract, in the token's smallest unit.",
+		}),
+		lockedFunds: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "payments_account_locked_funds_base_units",
+			Help: "Funds currently locked against active payment rails.",
+		}),
+		availableFunds: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "payments_account_available_funds_base_units",
+			Help: "Funds minus current lockup - the free balance available for new rails.",
+		}),
+		rateAllowance: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "payments_operator_rate_allowance_base_units",
+			Help: "Rate allowance granted to the service contract as an operator, in base units per epoch.",
+		}),
+		rateUsage: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "payments_operator_rate_usage_base_units",
+			Help: "Rate allowance currently used by the service contract, in base units per epoch.",
+		}),
+		lockupAllowance: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "payments_operator_lockup_allowance_base_units",
+			Help: "Lockup allowance granted to the service contract as an operator.",
+		}),
+		lockupUsage: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "payments_operator_lockup_usage_base_units",
+			Help: "Lockup allowance currently used by the service contract.",
+		}),
+		operatorApproved: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "payments_operator_approved",
+			Help: "1 if the service contract is approved as an operator on the payer's account, 0 otherwise.",
+		}),
+		railPaymentRate: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "payments_rail_payment_rate_base_units",
+			Help: "Payment rate of a payment rail, in base units per epoch.",
+		}, []string{"rail_id", "storage_node"}),
+		railSettledEpoch: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "payments_rail_settled_up_to_epoch",
+			Help: "Epoch a payment rail has been settled up to.",
+		}, []string{"rail_id", "storage_node"}),
+	}
+
+	registry.MustRegister(
+		m.funds, m.lockedFunds, m.availableFunds,
+		m.rateAllowance, m.rateUsage, m.lockupAllowance, m.lockupUsage, m.operatorApproved,
+		m.railPaymentRate, m.railSettledEpoch,
+	)
+	return m
+}
+
+func (m *paymentsMetrics) observe(result *StatusResult) {
+	m.funds.Set(bigStringToFloat(result.Funds))
+	m.lockedFunds.Set(bigStringToFloat(result.LockedFunds))
+	m.availableFunds.Set(bigStringToFloat(result.AvailableFunds))
+	m.rateAllowance.Set(bigStringToFloat(result.RateAllowance))
+	m.rateUsage.Set(bigStringToFloat(result.RateUsage))
+	m.lockupAllowance.Set(bigStringToFloat(result.LockupAllowance))
+	m.lockupUsage.Set(bigStringToFloat(result.LockupUsage))
+	if result.OperatorApproved {
+		m.operatorApproved.Set(1)
+	} else {
+		m.operatorApproved.Set(0)
+	}
+
+	m.railPaymentRate.Reset()
+	m.railSettledEpoch.Reset()
+	for _, rail := range result.Rails {
+		if rail.Terminated {
+			continue
+		}
+		labels := prometheus.Labels{"rail_id": rail.RailID, "storage_node": rail.StorageNode}
+		m.railPaymentRate.With(labels).Set(bigStringToFloat(rail.PaymentRate))
+		m.railSettledEpoch.With(labels).Set(bigStringToFloat(rail.SettledUpToEpoch))
+	}
+}
+
+func (m *paymentsMetrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// bigStringToFloat converts a decimal big.Int string, as found in
+// StatusResult's fields, to a float64 for Prometheus, which has no
+// arbitrary-precision numeric type. Empty strings (e.g. RateAllowance when
+// the operator isn't approved) report zero.
+func bigStringToFloat(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return 0
+	}
+	f := new(big.Float).SetInt(n)
+	value, _ := f.Float64()
+	return value
+}
+
+func runMetrics(cobraCmd *cobra.Command, args []string) error {
+	ctx, cancel := signal.NotifyContext(cobraCmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	m := newPaymentsMetrics()
+
+	railOpts, err := enumerateRailsOptions(metricsProviderCachePath, metricsProviderCacheTTL, metricsProviderPageSize, metricsProviderConcurrency)
+	if err != nil {
+		return err
+	}
+
+	refresh := func() {
+		result, err := CollectStatus(ctx, cfg, metricsAccountIndex, railOpts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: refreshing payments status: %v\n", err)
+			return
+		}
+		m.observe(result)
+	}
+	refresh()
+
+	go func() {
+		ticker := time.NewTicker(metricsInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refresh()
+			}
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.handler())
+
+	server := &http.Server{Addr: metricsListenAddr, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("Serving payments metrics on %s/metrics\n", metricsListenAddr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("metrics server failed: %w", err)
+	}
+	return nil
+}
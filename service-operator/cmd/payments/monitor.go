@@ -0,0 +1,191 @@
+package payments
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/refill"
+)
+
+var (
+	monitorInterval     time.Duration
+	monitorAutoRefill   bool
+	monitorThreshold    string
+	monitorTarget       string
+	monitorDailyCap     string
+	monitorAbsoluteCap  string
+	monitorAccountIndex uint32
+	monitorMetricsAddr  string
+	monitorLogFormat    string
+)
+
+var monitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Run a long-lived process that watches the payer's Payments contract balance",
+	Long: `Run a long-lived process that polls the payer's free balance (funds minus current lockup)
+in the Payments contract on a fixed interval, logging it and, with --metrics-addr, exporting it
+as a Prometheus gauge so it can be alerted on.
+
+With --auto-refill, once the free balance drops below refill.threshold (or --threshold), the
+monitor deposits up to refill.target (or --target) automatically, guarded by --daily-cap,
+--absolute-cap, and a check that the payer's wallet actually holds enough USDFC to cover the
+deposit. Without --auto-refill, monitor only observes and logs - useful for alerting without
+risking an unattended deposit.
+
+refill.threshold and refill.target can be set in the config file instead of passed as flags:
+
+  refill:
+    threshold: "1000000000000000000"
+    target: "10000000000000000000"
+    daily_cap: "50000000000000000000"
+
+Examples:
+  # Watch balance only, exporting Prometheus metrics
+  service-operator payments monitor --interval 5m --metrics-addr :9090
+
+  # Auto-refill from the configured refill.threshold/refill.target
+  service-operator payments monitor --auto-refill --interval 5m`,
+	RunE: runMonitor,
+}
+
+func init() {
+	monitorCmd.Flags().DurationVar(&monitorInterval, "interval", 5*time.Minute, "How often to check the payer's balance")
+	monitorCmd.Flags().BoolVar(&monitorAutoRefill, "auto-refill", false, "Automatically deposit funds when the balance drops below the threshold, instead of only observing it")
+	monitorCmd.Flags().StringVar(&monitorThreshold, "threshold", "", "Free balance (token base units) below which a refill is triggered (overrides refill.threshold)")
+	monitorCmd.Flags().StringVar(&monitorTarget, "target", "", "Free balance (token base units) a refill tops up to (overrides refill.target)")
+	monitorCmd.Flags().StringVar(&monitorDailyCap, "daily-cap", "", "Maximum total (token base units) to deposit per rolling 24h window (overrides refill.daily_cap)")
+	monitorCmd.Flags().StringVar(&monitorAbsoluteCap, "absolute-cap", "", "Maximum total (token base units) to ever deposit over this process's lifetime (overrides refill.absolute_cap)")
+	monitorCmd.Flags().Uint32Var(&monitorAccountIndex, "account-index", 0, "HD account index to derive the payer signer from (requires signers.payer.mnemonic or seed_file)")
+	monitorCmd.Flags().StringVar(&monitorMetricsAddr, "metrics-addr", "", "Address to serve Prometheus metrics on, e.g. :9090 (disabled if empty)")
+	monitorCmd.Flags().StringVar(&monitorLogFormat, "log-format", "text", "Log format: text or json")
+}
+
+func runMonitor(cobraCmd *cobra.Command, args []string) error {
+	ctx, cancel := signal.NotifyContext(cobraCmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	logger, err := newLogger(monitorLogFormat)
+	if err != nil {
+		return err
+	}
+
+	threshold, target, dailyCap, absoluteCap, err := resolveRefillAmounts(cfg)
+	if err != nil {
+		return err
+	}
+	if monitorAutoRefill {
+		if threshold == nil {
+			return fmt.Errorf("--threshold or refill.threshold is required with --auto-refill")
+		}
+		if target == nil {
+			return fmt.Errorf("--target or refill.target is required with --auto-refill")
+		}
+		if target.Cmp(threshold) <= 0 {
+			return fmt.Errorf("refill target %s must be greater than threshold %s", target.String(), threshold.String())
+		}
+	}
+
+	client, err := ethclient.DialContext(ctx, cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+
+	signerManager := contract.NewSignerManager(cfg)
+	txSigner, err := signerManager.LoadPayerTxSignerAtIndex(ctx, monitorAccountIndex)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("loading payer signer: %w", err)
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("getting chain ID: %w", err)
+	}
+	client.Close()
+
+	m := refill.New(refill.Config{
+		RPCUrl:          cfg.RPCUrl,
+		PaymentsAddress: cfg.PaymentsAddr(),
+		TokenAddress:    cfg.TokenAddr(),
+		PayerAddress:    txSigner.Address(),
+		TxSigner:        txSigner,
+		ChainID:         chainID,
+		Interval:        monitorInterval,
+		Threshold:       threshold,
+		Target:          target,
+		DailyCap:        dailyCap,
+		AbsoluteCap:     absoluteCap,
+		AutoRefill:      monitorAutoRefill,
+		MetricsAddr:     monitorMetricsAddr,
+		Logger:          logger,
+	})
+
+	logger.Info("starting balance monitor",
+		"payer", txSigner.Address().Hex(),
+		"interval", monitorInterval.String(),
+		"auto_refill", monitorAutoRefill,
+	)
+
+	return m.Run(ctx)
+}
+
+// resolveRefillAmounts parses threshold/target/daily-cap/absolute-cap,
+// preferring the --threshold/--target/--daily-cap/--absolute-cap flags over
+// the matching refill.* config fields when both are set. Any of the four may
+// come back nil if neither a flag nor a config field set it.
+func resolveRefillAmounts(cfg *config.Config) (threshold, target, dailyCap, absoluteCap *big.Int, err error) {
+	pick := func(flagValue string, fromConfig string) string {
+		if flagValue != "" {
+			return flagValue
+		}
+		return fromConfig
+	}
+
+	var refillThreshold, refillTarget, refillDailyCap, refillAbsoluteCap string
+	if cfg.Refill != nil {
+		refillThreshold = cfg.Refill.Threshold
+		refillTarget = cfg.Refill.Target
+		refillDailyCap = cfg.Refill.DailyCap
+		refillAbsoluteCap = cfg.Refill.AbsoluteCap
+	}
+
+	parse := func(flagName, raw string) (*big.Int, error) {
+		if raw == "" {
+			return nil, nil
+		}
+		amount := new(big.Int)
+		if _, ok := amount.SetString(raw, 10); !ok {
+			return nil, fmt.Errorf("invalid %s: %s", flagName, raw)
+		}
+		return amount, nil
+	}
+
+	if threshold, err = parse("--threshold", pick(monitorThreshold, refillThreshold)); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if target, err = parse("--target", pick(monitorTarget, refillTarget)); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if dailyCap, err = parse("--daily-cap", pick(monitorDailyCap, refillDailyCap)); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if absoluteCap, err = parse("--absolute-cap", pick(monitorAbsoluteCap, refillAbsoluteCap)); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return threshold, target, dailyCap, absoluteCap, nil
+}
@@ -1,25 +1,54 @@
 package payments
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"strings"
+	"sync"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/spf13/cobra"
 
 	"github.com/storacha/filecoin-services/service-operator/internal/config"
 	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
 	"github.com/storacha/filecoin-services/service-operator/internal/payments"
 )
 
 var (
-	settleRailID     string
-	settleUntilEpoch string
-	settleAll        bool
+	settleRailID       string
+	settleUntilEpoch   string
+	settleAll          bool
+	settleAccountIndex uint32
+	settleConcurrency  int
+	settleFromFile     string
+	settleResultsFile  string
+	settleDryRun       bool
 )
 
+// SettleRailResult is a single rail's outcome in the --output=json payload
+// for `payments settle`.
+type SettleRailResult struct {
+	RailID                  string `json:"railId"`
+	Settled                 bool   `json:"settled"`
+	DryRun                  bool   `json:"dryRun,omitempty"`
+	Error                   string `json:"error,omitempty"`
+	TransactionHash         string `json:"transactionHash,omitempty"`
+	SettledUpToEpoch        string `json:"settledUpToEpoch,omitempty"`
+	TotalSettledAmount      string `json:"totalSettledAmount,omitempty"`
+	TotalNetPayeeAmount     string `json:"totalNetPayeeAmount,omitempty"`
+	TotalOperatorCommission string `json:"totalOperatorCommission,omitempty"`
+	GasEstimate             uint64 `json:"gasEstimate,omitempty"`
+}
+
+// SettleResult is the --output=json payload for `payments settle`.
+type SettleResult struct {
+	Rails     []SettleRailResult `json:"rails"`
+	Succeeded int                `json:"succeeded"`
+	Total     int                `json:"total"`
+}
+
 var settleCmd = &cobra.Command{
 	Use:   "settle",
 	Short: "Settle payment rails to transfer locked funds",
@@ -39,7 +68,15 @@ Examples:
   service-operator payments settle --rail-id 1 --until-epoch 1000000
 
   # Settle all rails for this service provider
-  service-operator payments settle --all`,
+  service-operator payments settle --all
+
+  # Settle thousands of rails from a database export, skipping any whose
+  # projected payout doesn't clear the row's minimum and recording a result
+  # line per row
+  service-operator payments settle --from-file rails.csv --results-file results.jsonl
+
+  # Preview settlement amounts and gas cost without broadcasting
+  service-operator payments settle --rail-id 1 --dry-run`,
 	RunE: runSettle,
 }
 
@@ -47,6 +84,11 @@ func init() {
 	settleCmd.Flags().StringVar(&settleRailID, "rail-id", "", "Rail ID to settle")
 	settleCmd.Flags().StringVar(&settleUntilEpoch, "until-epoch", "", "Settle up to this epoch (defaults to current block number)")
 	settleCmd.Flags().BoolVar(&settleAll, "all", false, "Settle all rails for this service provider")
+	settleCmd.Flags().Uint32Var(&settleAccountIndex, "account-index", 0, "HD account index to derive the payer signer from (requires signers.payer.mnemonic or seed_file)")
+	settleCmd.Flags().IntVar(&settleConcurrency, "concurrency", 4, "Maximum number of rails to sign and broadcast concurrently with --all or --from-file")
+	settleCmd.Flags().StringVar(&settleFromFile, "from-file", "", "Path to a CSV or JSONL manifest of rows {rail_id, until_epoch, max_gas_price, min_expected_payee_amount} to settle in bulk, in place of --rail-id/--all")
+	settleCmd.Flags().StringVar(&settleResultsFile, "results-file", "", "Path to write one JSONL result line per --from-file row (required with --from-file)")
+	settleCmd.Flags().BoolVar(&settleDryRun, "dry-run", false, "Simulate settlement via eth_call and print the projected payout and gas cost without broadcasting")
 }
 
 func runSettle(cobraCmd *cobra.Command, args []string) error {
@@ -57,9 +99,18 @@ func runSettle(cobraCmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	jsonMode := output.Selected() == output.JSON
+
+	if settleFromFile != "" {
+		if settleAll || settleRailID != "" || settleUntilEpoch != "" {
+			return fmt.Errorf("--from-file cannot be combined with --rail-id, --all, or --until-epoch")
+		}
+		return runBatchSettle(ctx, cfg, jsonMode)
+	}
+
 	// Validate flags
 	if !settleAll && settleRailID == "" {
-		return fmt.Errorf("either --rail-id or --all must be specified")
+		return fmt.Errorf("either --rail-id, --all, or --from-file must be specified")
 	}
 	if settleAll && settleRailID != "" {
 		return fmt.Errorf("cannot specify both --rail-id and --all")
@@ -71,14 +122,17 @@ func runSettle(cobraCmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	// Create signer manager and load payer's private key for signing
+	// Create signer manager and load the payer signer, which may be backed by
+	// a local keystore, an external JSON-RPC signer, a Ledger, or a KMS key
+	// depending on signers.payer.backend - settlement doesn't need to know
+	// which.
 	signerManager := contract.NewSignerManager(cfg)
-	privateKey, err := signerManager.LoadPayerSigner()
+	txSigner, err := signerManager.LoadPayerTxSignerAtIndex(ctx, settleAccountIndex)
 	if err != nil {
 		return fmt.Errorf("loading payer signer: %w", err)
 	}
 
-	serviceProviderAddr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	serviceProviderAddr := txSigner.Address()
 
 	// Get chain ID
 	chainID, err := client.ChainID(ctx)
@@ -102,6 +156,10 @@ func runSettle(cobraCmd *cobra.Command, args []string) error {
 		}
 
 		if len(rails) == 0 {
+			if jsonMode {
+				_, err := output.Emit(SettleResult{})
+				return err
+			}
 			fmt.Println("No payment rails found for this service provider.")
 			return nil
 		}
@@ -114,11 +172,17 @@ func runSettle(cobraCmd *cobra.Command, args []string) error {
 		}
 
 		if len(railIDs) == 0 {
+			if jsonMode {
+				_, err := output.Emit(SettleResult{})
+				return err
+			}
 			fmt.Println("No active payment rails found for this service provider.")
 			return nil
 		}
 
-		fmt.Printf("Found %d active payment rail(s) to settle\n\n", len(railIDs))
+		if !jsonMode {
+			fmt.Printf("Found %d active payment rail(s) to settle\n\n", len(railIDs))
+		}
 	} else {
 		// Parse single rail ID
 		railID := new(big.Int)
@@ -142,64 +206,176 @@ func runSettle(cobraCmd *cobra.Command, args []string) error {
 			return fmt.Errorf("getting current block number: %w", err)
 		}
 		untilEpoch = new(big.Int).SetUint64(blockNumber)
-		fmt.Printf("Using current block number as until epoch: %s\n\n", untilEpoch.String())
+		if !jsonMode {
+			fmt.Printf("Using current block number as until epoch: %s\n\n", untilEpoch.String())
+		}
 	}
 
-	// Settle each rail
-	successCount := 0
-	for _, railID := range railIDs {
-		fmt.Printf("Settling rail %s...\n", railID.String())
+	// Assign each rail a distinct nonce up front so concurrent broadcasts from
+	// the same payer address never collide or race the node's pending-nonce
+	// view.
+	baseNonce, err := client.PendingNonceAt(ctx, serviceProviderAddr)
+	if err != nil {
+		return fmt.Errorf("getting pending nonce: %w", err)
+	}
 
-		// Query rail info first
-		railInfo, err := contract.QueryRailInfo(ctx, cfg.RPCUrl, cfg.PaymentsAddr(), railID)
-		if err != nil {
-			fmt.Printf("  ❌ Error querying rail info: %v\n\n", err)
-			continue
+	// Settle rails with up to settleConcurrency in flight at once; each
+	// goroutine writes to its own index, so no locking is needed to collect
+	// results in rail order.
+	railResults := make([]SettleRailResult, len(railIDs))
+	concurrency := settleConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var printMu sync.Mutex
+
+	for i, railID := range railIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, railID *big.Int, nonce uint64) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, detail := settleOneRail(ctx, cfg, txSigner, chainID, railID, untilEpoch, nonce, decimals, settleDryRun)
+			railResults[i] = result
+
+			if !jsonMode {
+				printMu.Lock()
+				fmt.Print(detail)
+				printMu.Unlock()
+			}
+		}(i, railID, baseNonce+uint64(i))
+	}
+	wg.Wait()
+
+	successCount := 0
+	for _, result := range railResults {
+		if result.Settled || (result.DryRun && result.Error == "") {
+			successCount++
 		}
+	}
 
-		fmt.Printf("  Payer:        %s\n", railInfo.From.Hex())
-		fmt.Printf("  Payee:        %s\n", railInfo.To.Hex())
-		fmt.Printf("  Operator:     %s\n", railInfo.Operator.Hex())
-		fmt.Printf("  Settled up to: epoch %s\n", railInfo.SettledUpTo.String())
-		fmt.Printf("  Payment rate: %s/epoch (%s/epoch)\n",
-			railInfo.PaymentRate.String(),
-			payments.FormatTokenAmount(railInfo.PaymentRate, decimals))
+	if jsonMode {
+		_, err := output.Emit(SettleResult{Rails: railResults, Succeeded: successCount, Total: len(railIDs)})
+		return err
+	}
 
-		// Create transaction auth
-		auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
-		if err != nil {
-			fmt.Printf("  ❌ Error creating transaction auth: %v\n\n", err)
-			continue
-		}
+	if settleDryRun {
+		fmt.Printf("Dry run complete: %d of %d rail(s) simulated successfully\n", successCount, len(railIDs))
+	} else {
+		fmt.Printf("Settlement complete: %d of %d rail(s) settled successfully\n", successCount, len(railIDs))
+	}
+
+	return nil
+}
 
-		// Settle the rail
-		result, err := contract.SettleRail(ctx, cfg.RPCUrl, cfg.PaymentsAddr(), auth, railID, untilEpoch)
+// settleOneRail queries, signs, and broadcasts the settlement of a single
+// rail at a pre-assigned nonce, returning both its SettleRailResult and a
+// human-readable detail block. The detail block is returned rather than
+// printed directly so a caller running many of these concurrently can print
+// it atomically once the goroutine finishes, instead of interleaving
+// partial lines from different rails.
+func settleOneRail(
+	ctx context.Context,
+	cfg *config.Config,
+	txSigner contract.TxSigner,
+	chainID *big.Int,
+	railID *big.Int,
+	untilEpoch *big.Int,
+	nonce uint64,
+	decimals uint8,
+	dryRun bool,
+) (SettleRailResult, string) {
+	var b strings.Builder
+	if dryRun {
+		fmt.Fprintf(&b, "Simulating settlement of rail %s (dry run)...\n", railID.String())
+	} else {
+		fmt.Fprintf(&b, "Settling rail %s...\n", railID.String())
+	}
+
+	railInfo, err := contract.QueryRailInfo(ctx, cfg.RPCUrl, cfg.PaymentsAddr(), railID)
+	if err != nil {
+		fmt.Fprintf(&b, "  ❌ Error querying rail info: %v\n\n", err)
+		return SettleRailResult{RailID: railID.String(), Error: err.Error()}, b.String()
+	}
+
+	fmt.Fprintf(&b, "  Payer:        %s\n", railInfo.From.Hex())
+	fmt.Fprintf(&b, "  Payee:        %s\n", railInfo.To.Hex())
+	fmt.Fprintf(&b, "  Operator:     %s\n", railInfo.Operator.Hex())
+	fmt.Fprintf(&b, "  Settled up to: epoch %s\n", railInfo.SettledUpTo.String())
+	fmt.Fprintf(&b, "  Payment rate: %s/epoch (%s/epoch)\n",
+		railInfo.PaymentRate.String(),
+		payments.FormatTokenAmount(railInfo.PaymentRate, decimals))
+
+	if dryRun {
+		simulated, err := contract.SimulateSettleRail(ctx, cfg.RPCUrl, cfg.PaymentsAddr(), txSigner.Address(), railID, untilEpoch, "pending")
 		if err != nil {
-			fmt.Printf("  ❌ Settlement failed: %v\n\n", err)
-			continue
+			fmt.Fprintf(&b, "  ❌ Simulation failed: %v\n\n", err)
+			return SettleRailResult{RailID: railID.String(), DryRun: true, Error: err.Error()}, b.String()
 		}
 
-		fmt.Printf("  ✓ Settlement successful!\n")
-		fmt.Printf("  Transaction:  %s\n", result.TransactionHash.Hex())
-		fmt.Printf("  Settled up to: epoch %s\n", result.FinalSettledEpoch.String())
-		fmt.Printf("  Amount paid:  %s (%s)\n",
-			result.TotalSettledAmount.String(),
-			payments.FormatTokenAmount(result.TotalSettledAmount, decimals))
-		fmt.Printf("  Payee received: %s (%s)\n",
-			result.TotalNetPayeeAmount.String(),
-			payments.FormatTokenAmount(result.TotalNetPayeeAmount, decimals))
-		fmt.Printf("  Commission:   %s (%s)\n",
-			result.TotalOperatorCommission.String(),
-			payments.FormatTokenAmount(result.TotalOperatorCommission, decimals))
-		if result.Note != "" {
-			fmt.Printf("  Note:         %s\n", result.Note)
+		fmt.Fprintf(&b, "  Projected settled up to: epoch %s\n", simulated.FinalSettledEpoch.String())
+		fmt.Fprintf(&b, "  Projected amount:        %s (%s)\n",
+			simulated.TotalSettledAmount.String(),
+			payments.FormatTokenAmount(simulated.TotalSettledAmount, decimals))
+		fmt.Fprintf(&b, "  Projected payee receives: %s (%s)\n",
+			simulated.TotalNetPayeeAmount.String(),
+			payments.FormatTokenAmount(simulated.TotalNetPayeeAmount, decimals))
+		fmt.Fprintf(&b, "  Projected commission:    %s (%s)\n",
+			simulated.TotalOperatorCommission.String(),
+			payments.FormatTokenAmount(simulated.TotalOperatorCommission, decimals))
+		fmt.Fprintf(&b, "  Gas estimate:            %d\n", simulated.GasUsed)
+		if simulated.Note != "" {
+			fmt.Fprintf(&b, "  Note:                    %s\n", simulated.Note)
 		}
-		fmt.Println()
-
-		successCount++
+		fmt.Fprintln(&b)
+
+		return SettleRailResult{
+			RailID:                  railID.String(),
+			DryRun:                  true,
+			SettledUpToEpoch:        simulated.FinalSettledEpoch.String(),
+			TotalSettledAmount:      simulated.TotalSettledAmount.String(),
+			TotalNetPayeeAmount:     simulated.TotalNetPayeeAmount.String(),
+			TotalOperatorCommission: simulated.TotalOperatorCommission.String(),
+			GasEstimate:             simulated.GasUsed,
+		}, b.String()
 	}
 
-	fmt.Printf("Settlement complete: %d of %d rail(s) settled successfully\n", successCount, len(railIDs))
+	auth := contract.CreateTransactorFromSigner(ctx, txSigner, chainID)
+	auth.Nonce = new(big.Int).SetUint64(nonce)
 
-	return nil
+	settleResult, err := contract.SettleRail(ctx, cfg.RPCUrl, cfg.PaymentsAddr(), auth, railID, untilEpoch)
+	if err != nil {
+		fmt.Fprintf(&b, "  ❌ Settlement failed: %v\n\n", err)
+		return SettleRailResult{RailID: railID.String(), Error: err.Error()}, b.String()
+	}
+
+	fmt.Fprintf(&b, "  ✓ Settlement successful!\n")
+	fmt.Fprintf(&b, "  Transaction:  %s\n", settleResult.TransactionHash.Hex())
+	fmt.Fprintf(&b, "  Settled up to: epoch %s\n", settleResult.FinalSettledEpoch.String())
+	fmt.Fprintf(&b, "  Amount paid:  %s (%s)\n",
+		settleResult.TotalSettledAmount.String(),
+		payments.FormatTokenAmount(settleResult.TotalSettledAmount, decimals))
+	fmt.Fprintf(&b, "  Payee received: %s (%s)\n",
+		settleResult.TotalNetPayeeAmount.String(),
+		payments.FormatTokenAmount(settleResult.TotalNetPayeeAmount, decimals))
+	fmt.Fprintf(&b, "  Commission:   %s (%s)\n",
+		settleResult.TotalOperatorCommission.String(),
+		payments.FormatTokenAmount(settleResult.TotalOperatorCommission, decimals))
+	if settleResult.Note != "" {
+		fmt.Fprintf(&b, "  Note:         %s\n", settleResult.Note)
+	}
+	fmt.Fprintln(&b)
+
+	return SettleRailResult{
+		RailID:                  railID.String(),
+		Settled:                 true,
+		TransactionHash:         settleResult.TransactionHash.Hex(),
+		SettledUpToEpoch:        settleResult.FinalSettledEpoch.String(),
+		TotalSettledAmount:      settleResult.TotalSettledAmount.String(),
+		TotalNetPayeeAmount:     settleResult.TotalNetPayeeAmount.String(),
+		TotalOperatorCommission: settleResult.TotalOperatorCommission.String(),
+	}, b.String()
 }
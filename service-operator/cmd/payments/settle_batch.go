@@ -0,0 +1,425 @@
+package payments
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
+	"github.com/storacha/filecoin-services/service-operator/internal/payments"
+)
+
+// BatchSettleRow is one row of a --from-file settlement manifest: a rail to
+// settle plus the guardrails an off-chain scheduler wants enforced on it.
+type BatchSettleRow struct {
+	RailID *big.Int
+	// UntilEpoch defaults to the current block number when unset.
+	UntilEpoch *big.Int
+	// MaxGasPrice caps the gas price the settlement transaction is signed
+	// with; the actual price used is min(suggested, MaxGasPrice). Unset
+	// means no cap.
+	MaxGasPrice *big.Int
+	// MinExpectedPayeeAmount, when set, is checked against a dry-run
+	// simulation before broadcasting; rows projected to pay the payee less
+	// than this are skipped rather than settled.
+	MinExpectedPayeeAmount *big.Int
+}
+
+// BatchSettleRowResult is one JSONL line written to --results-file.
+type BatchSettleRowResult struct {
+	RailID                  string `json:"railId"`
+	Settled                 bool   `json:"settled"`
+	Skipped                 bool   `json:"skipped"`
+	SkipReason              string `json:"skipReason,omitempty"`
+	Error                   string `json:"error,omitempty"`
+	TransactionHash         string `json:"transactionHash,omitempty"`
+	SettledUpToEpoch        string `json:"settledUpToEpoch,omitempty"`
+	TotalSettledAmount      string `json:"totalSettledAmount,omitempty"`
+	TotalNetPayeeAmount     string `json:"totalNetPayeeAmount,omitempty"`
+	TotalOperatorCommission string `json:"totalOperatorCommission,omitempty"`
+}
+
+// BatchSettleResult is the --output=json summary for a --from-file run.
+type BatchSettleResult struct {
+	Total   int `json:"total"`
+	Settled int `json:"settled"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+// parseBatchManifest reads a --from-file settlement manifest, dispatching on
+// file extension: ".csv" for CSV, anything else (".jsonl", ".json", or no
+// extension) for one JSON object per line.
+func parseBatchManifest(path string) ([]BatchSettleRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening manifest %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return parseBatchManifestCSV(f)
+	}
+	return parseBatchManifestJSONL(f)
+}
+
+func parseBatchManifestCSV(f *os.File) ([]BatchSettleRow, error) {
+	r := csv.NewReader(f)
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV manifest: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV manifest has no rows")
+	}
+
+	header := records[0]
+	col := make(map[string]int, len(header))
+	for i, name := range header {
+		col[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	if _, ok := col["rail_id"]; !ok {
+		return nil, fmt.Errorf("CSV manifest is missing required header column rail_id")
+	}
+
+	var rows []BatchSettleRow
+	for lineNum, record := range records[1:] {
+		get := func(name string) string {
+			if i, ok := col[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row, err := newBatchSettleRow(get("rail_id"), get("until_epoch"), get("max_gas_price"), get("min_expected_payee_amount"))
+		if err != nil {
+			return nil, fmt.Errorf("CSV manifest row %d: %w", lineNum+2, err)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// batchManifestJSONRow is the JSONL encoding of one BatchSettleRow; all
+// fields are strings so arbitrarily large rail IDs and amounts survive
+// round-tripping without losing precision the way JSON numbers would.
+type batchManifestJSONRow struct {
+	RailID                 string `json:"rail_id"`
+	UntilEpoch             string `json:"until_epoch"`
+	MaxGasPrice            string `json:"max_gas_price"`
+	MinExpectedPayeeAmount string `json:"min_expected_payee_amount"`
+}
+
+func parseBatchManifestJSONL(f *os.File) ([]BatchSettleRow, error) {
+	var rows []BatchSettleRow
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var jsonRow batchManifestJSONRow
+		if err := json.Unmarshal([]byte(line), &jsonRow); err != nil {
+			return nil, fmt.Errorf("JSONL manifest line %d: %w", lineNum, err)
+		}
+
+		row, err := newBatchSettleRow(jsonRow.RailID, jsonRow.UntilEpoch, jsonRow.MaxGasPrice, jsonRow.MinExpectedPayeeAmount)
+		if err != nil {
+			return nil, fmt.Errorf("JSONL manifest line %d: %w", lineNum, err)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading JSONL manifest: %w", err)
+	}
+	return rows, nil
+}
+
+func newBatchSettleRow(railIDStr, untilEpochStr, maxGasPriceStr, minExpectedPayeeAmountStr string) (BatchSettleRow, error) {
+	if railIDStr == "" {
+		return BatchSettleRow{}, fmt.Errorf("rail_id is required")
+	}
+	railID, ok := new(big.Int).SetString(railIDStr, 10)
+	if !ok {
+		return BatchSettleRow{}, fmt.Errorf("invalid rail_id: %s", railIDStr)
+	}
+
+	row := BatchSettleRow{RailID: railID}
+
+	if untilEpochStr != "" {
+		untilEpoch, ok := new(big.Int).SetString(untilEpochStr, 10)
+		if !ok {
+			return BatchSettleRow{}, fmt.Errorf("invalid until_epoch: %s", untilEpochStr)
+		}
+		row.UntilEpoch = untilEpoch
+	}
+	if maxGasPriceStr != "" {
+		maxGasPrice, ok := new(big.Int).SetString(maxGasPriceStr, 10)
+		if !ok {
+			return BatchSettleRow{}, fmt.Errorf("invalid max_gas_price: %s", maxGasPriceStr)
+		}
+		row.MaxGasPrice = maxGasPrice
+	}
+	if minExpectedPayeeAmountStr != "" {
+		minExpectedPayeeAmount, ok := new(big.Int).SetString(minExpectedPayeeAmountStr, 10)
+		if !ok {
+			return BatchSettleRow{}, fmt.Errorf("invalid min_expected_payee_amount: %s", minExpectedPayeeAmountStr)
+		}
+		row.MinExpectedPayeeAmount = minExpectedPayeeAmount
+	}
+	return row, nil
+}
+
+// runBatchSettle drives settlement of every row in --from-file, writing one
+// JSONL result line per row to --results-file as it completes (rather than
+// buffering the whole run in memory, since a manifest may cover thousands of
+// rails).
+func runBatchSettle(ctx context.Context, cfg *config.Config, jsonMode bool) error {
+	if settleResultsFile == "" {
+		return fmt.Errorf("--results-file is required with --from-file")
+	}
+
+	rows, err := parseBatchManifest(settleFromFile)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("manifest %s has no rows", settleFromFile)
+	}
+
+	resultsOut, err := os.Create(settleResultsFile)
+	if err != nil {
+		return fmt.Errorf("creating results file %s: %w", settleResultsFile, err)
+	}
+	defer resultsOut.Close()
+	resultsEnc := json.NewEncoder(resultsOut)
+
+	client, err := ethclient.Dial(cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+	defer client.Close()
+
+	signerManager := contract.NewSignerManager(cfg)
+	txSigner, err := signerManager.LoadPayerTxSignerAtIndex(ctx, settleAccountIndex)
+	if err != nil {
+		return fmt.Errorf("loading payer signer: %w", err)
+	}
+	serviceProviderAddr := txSigner.Address()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("getting chain ID: %w", err)
+	}
+
+	decimals, err := GetTokenDecimals(ctx, client, cfg.TokenAddr())
+	if err != nil {
+		return fmt.Errorf("querying token decimals: %w", err)
+	}
+
+	currentBlock, err := client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("getting current block number: %w", err)
+	}
+	defaultUntilEpoch := new(big.Int).SetUint64(currentBlock)
+
+	baseNonce, err := client.PendingNonceAt(ctx, serviceProviderAddr)
+	if err != nil {
+		return fmt.Errorf("getting pending nonce: %w", err)
+	}
+
+	concurrency := settleConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var resultsMu sync.Mutex
+
+	counts := struct {
+		settled, skipped, failed int
+	}{}
+
+	// Simulation (which decides whether a row is skipped) and nonce
+	// assignment happen in separate passes: a row isn't assigned a nonce
+	// until it's confirmed it will actually broadcast, so a skipped row -
+	// the batch's expected common case when MinExpectedPayeeAmount is set -
+	// never gaps the nonce sequence for the rows that follow it.
+	untilEpochs := make([]*big.Int, len(rows))
+	for i, row := range rows {
+		untilEpoch := row.UntilEpoch
+		if untilEpoch == nil {
+			untilEpoch = defaultUntilEpoch
+		}
+		untilEpochs[i] = untilEpoch
+	}
+
+	type simOutcome struct {
+		proceed bool
+		result  BatchSettleRowResult
+	}
+	outcomes := make([]simOutcome, len(rows))
+	for i, row := range rows {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, row BatchSettleRow) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, proceed := simulateBatchSettleRow(ctx, cfg, serviceProviderAddr, row, untilEpochs[i], decimals)
+			outcomes[i] = simOutcome{proceed: proceed, result: result}
+		}(i, row)
+	}
+	wg.Wait()
+
+	nonce := baseNonce
+	for i, row := range rows {
+		outcome := outcomes[i]
+		if !outcome.proceed {
+			resultsMu.Lock()
+			if outcome.result.Skipped {
+				counts.skipped++
+			} else {
+				counts.failed++
+			}
+			if err := resultsEnc.Encode(outcome.result); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: writing result for rail %s: %v\n", row.RailID.String(), err)
+			}
+			resultsMu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(row BatchSettleRow, untilEpoch *big.Int, assignedNonce uint64) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := settleBatchRow(ctx, cfg, client, txSigner, chainID, row, untilEpoch, assignedNonce)
+
+			resultsMu.Lock()
+			if result.Settled {
+				counts.settled++
+			} else {
+				counts.failed++
+			}
+			if err := resultsEnc.Encode(result); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: writing result for rail %s: %v\n", row.RailID.String(), err)
+			}
+			resultsMu.Unlock()
+		}(row, untilEpochs[i], nonce)
+		nonce++
+	}
+	wg.Wait()
+
+	summary := BatchSettleResult{
+		Total:   len(rows),
+		Settled: counts.settled,
+		Skipped: counts.skipped,
+		Failed:  counts.failed,
+	}
+	if jsonMode {
+		_, err := output.Emit(summary)
+		return err
+	}
+
+	fmt.Printf("Batch settlement complete: %d settled, %d skipped, %d failed (of %d)\n",
+		summary.Settled, summary.Skipped, summary.Failed, summary.Total)
+	fmt.Printf("Results written to %s\n", settleResultsFile)
+	return nil
+}
+
+// simulateBatchSettleRow dry-runs row's settlement to enforce
+// MinExpectedPayeeAmount before any nonce is reserved for it. It reports
+// proceed=false both when the row should be skipped (the result's Skipped
+// field is set) and when the simulation itself errored (the result's Error
+// field is set); the caller is responsible for distinguishing the two when
+// tallying counts. proceed=true with a zero-value result means row has
+// nothing to check and should go straight to broadcasting.
+//
+// Running this ahead of nonce assignment matters because a skip is this
+// batch's expected common case whenever MinExpectedPayeeAmount is set, not
+// an edge case: assigning every row a nonce up front regardless of whether
+// it will actually broadcast would permanently gap the payer's nonce
+// sequence on every skip, stalling every row after it.
+func simulateBatchSettleRow(ctx context.Context, cfg *config.Config, from common.Address, row BatchSettleRow, untilEpoch *big.Int, decimals uint8) (result BatchSettleRowResult, proceed bool) {
+	railIDStr := row.RailID.String()
+
+	if row.MinExpectedPayeeAmount == nil {
+		return BatchSettleRowResult{}, true
+	}
+
+	simulated, err := contract.SimulateSettleRail(ctx, cfg.RPCUrl, cfg.PaymentsAddr(), from, row.RailID, untilEpoch, "pending")
+	if err != nil {
+		return BatchSettleRowResult{RailID: railIDStr, Error: fmt.Sprintf("simulating settlement: %v", err)}, false
+	}
+	if simulated.TotalNetPayeeAmount.Cmp(row.MinExpectedPayeeAmount) < 0 {
+		reason := fmt.Sprintf("projected payee amount %s (%s) is below min_expected_payee_amount %s (%s)",
+			simulated.TotalNetPayeeAmount.String(), payments.FormatTokenAmount(simulated.TotalNetPayeeAmount, decimals),
+			row.MinExpectedPayeeAmount.String(), payments.FormatTokenAmount(row.MinExpectedPayeeAmount, decimals))
+		return BatchSettleRowResult{RailID: railIDStr, Skipped: true, SkipReason: reason}, false
+	}
+	return BatchSettleRowResult{}, true
+}
+
+// settleBatchRow broadcasts a single manifest row's settlement at a capped
+// gas price when MaxGasPrice is set. The row is assumed to have already
+// cleared simulateBatchSettleRow's MinExpectedPayeeAmount check.
+func settleBatchRow(
+	ctx context.Context,
+	cfg *config.Config,
+	client *ethclient.Client,
+	txSigner contract.TxSigner,
+	chainID *big.Int,
+	row BatchSettleRow,
+	untilEpoch *big.Int,
+	nonce uint64,
+) BatchSettleRowResult {
+	railIDStr := row.RailID.String()
+
+	auth := contract.CreateTransactorFromSigner(ctx, txSigner, chainID)
+	auth.Nonce = new(big.Int).SetUint64(nonce)
+
+	if row.MaxGasPrice != nil {
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return BatchSettleRowResult{RailID: railIDStr, Error: fmt.Sprintf("suggesting gas price: %v", err)}
+		}
+		if gasPrice.Cmp(row.MaxGasPrice) > 0 {
+			gasPrice = row.MaxGasPrice
+		}
+		auth.GasPrice = gasPrice
+	}
+
+	settleResult, err := contract.SettleRail(ctx, cfg.RPCUrl, cfg.PaymentsAddr(), auth, row.RailID, untilEpoch)
+	if err != nil {
+		return BatchSettleRowResult{RailID: railIDStr, Error: err.Error()}
+	}
+
+	return BatchSettleRowResult{
+		RailID:                  railIDStr,
+		Settled:                 true,
+		TransactionHash:         settleResult.TransactionHash.Hex(),
+		SettledUpToEpoch:        settleResult.FinalSettledEpoch.String(),
+		TotalSettledAmount:      settleResult.TotalSettledAmount.String(),
+		TotalNetPayeeAmount:     settleResult.TotalNetPayeeAmount.String(),
+		TotalOperatorCommission: settleResult.TotalOperatorCommission.String(),
+	}
+}
@@ -0,0 +1,209 @@
+package payments
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
+	"github.com/storacha/filecoin-services/service-operator/internal/payments"
+)
+
+var (
+	simulateRailID       string
+	simulateUntilEpoch   string
+	simulateAll          bool
+	simulateAccountIndex uint32
+	simulateBlock        string
+)
+
+// SimulateRailResult is a single rail's projected outcome in the
+// --output=json payload for `payments simulate`.
+type SimulateRailResult struct {
+	RailID                  string `json:"railId"`
+	Simulated               bool   `json:"simulated"`
+	Error                   string `json:"error,omitempty"`
+	TotalSettledAmount      string `json:"totalSettledAmount,omitempty"`
+	TotalNetPayeeAmount     string `json:"totalNetPayeeAmount,omitempty"`
+	TotalOperatorCommission string `json:"totalOperatorCommission,omitempty"`
+	FinalSettledEpoch       string `json:"finalSettledEpoch,omitempty"`
+	Note                    string `json:"note,omitempty"`
+	GasUsed                 uint64 `json:"gasUsed,omitempty"`
+}
+
+// SimulateResult is the --output=json payload for `payments simulate`.
+type SimulateResult struct {
+	Rails     []SimulateRailResult `json:"rails"`
+	Succeeded int                  `json:"succeeded"`
+	Total     int                  `json:"total"`
+}
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Preview settling payment rails without broadcasting a transaction",
+	Long: `Dry-run settlement of payment rails via eth_call, printing the projected
+settlement amounts and gas cost without sending a transaction or paying the
+0.0013 FIL network fee.
+
+This is useful for previewing settlements in CI or before broadcasting a
+batch with 'payments settle --all'.
+
+Examples:
+  # Preview settling a specific rail against pending state
+  service-operator payments simulate --rail-id 1
+
+  # Preview settling all rails against the latest finalized block
+  service-operator payments simulate --all --block latest`,
+	RunE: runSimulate,
+}
+
+func init() {
+	simulateCmd.Flags().StringVar(&simulateRailID, "rail-id", "", "Rail ID to simulate settling")
+	simulateCmd.Flags().StringVar(&simulateUntilEpoch, "until-epoch", "", "Settle up to this epoch (defaults to current block number)")
+	simulateCmd.Flags().BoolVar(&simulateAll, "all", false, "Simulate settling all rails for this service provider")
+	simulateCmd.Flags().Uint32Var(&simulateAccountIndex, "account-index", 0, "HD account index the payer would settle from (requires signers.payer.mnemonic or seed_file)")
+	simulateCmd.Flags().StringVar(&simulateBlock, "block", "pending", "State to simulate against: \"pending\", \"latest\", or a block number")
+}
+
+func runSimulate(cobraCmd *cobra.Command, args []string) error {
+	ctx := cobraCmd.Context()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	jsonMode := output.Selected() == output.JSON
+
+	if !simulateAll && simulateRailID == "" {
+		return fmt.Errorf("either --rail-id or --all must be specified")
+	}
+	if simulateAll && simulateRailID != "" {
+		return fmt.Errorf("cannot specify both --rail-id and --all")
+	}
+
+	client, err := ethclient.Dial(cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+	defer client.Close()
+
+	// Simulation never signs or broadcasts anything, so it only needs the
+	// payer's address - which a Ledger, external, or KMS signer can report
+	// without touching the device.
+	signerManager := contract.NewSignerManager(cfg)
+	txSigner, err := signerManager.LoadPayerTxSignerAtIndex(ctx, simulateAccountIndex)
+	if err != nil {
+		return fmt.Errorf("loading payer signer: %w", err)
+	}
+	serviceProviderAddr := txSigner.Address()
+
+	decimals, err := GetTokenDecimals(ctx, client, cfg.TokenAddr())
+	if err != nil {
+		return fmt.Errorf("querying token decimals: %w", err)
+	}
+
+	var railIDs []*big.Int
+	if simulateAll {
+		rails, err := contract.QueryRailsForPayee(ctx, cfg.RPCUrl, cfg.PaymentsAddr(), serviceProviderAddr, cfg.TokenAddr())
+		if err != nil {
+			return fmt.Errorf("querying rails for payee: %w", err)
+		}
+
+		for _, rail := range rails {
+			if !rail.IsTerminated {
+				railIDs = append(railIDs, rail.RailId)
+			}
+		}
+
+		if len(railIDs) == 0 {
+			if jsonMode {
+				_, err := output.Emit(SimulateResult{})
+				return err
+			}
+			fmt.Println("No active payment rails found for this service provider.")
+			return nil
+		}
+	} else {
+		railID := new(big.Int)
+		if _, ok := railID.SetString(simulateRailID, 10); !ok {
+			return fmt.Errorf("invalid rail ID: %s", simulateRailID)
+		}
+		railIDs = []*big.Int{railID}
+	}
+
+	var untilEpoch *big.Int
+	if simulateUntilEpoch != "" {
+		untilEpoch = new(big.Int)
+		if _, ok := untilEpoch.SetString(simulateUntilEpoch, 10); !ok {
+			return fmt.Errorf("invalid until epoch: %s", simulateUntilEpoch)
+		}
+	} else {
+		blockNumber, err := client.BlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("getting current block number: %w", err)
+		}
+		untilEpoch = new(big.Int).SetUint64(blockNumber)
+	}
+
+	railResults := make([]SimulateRailResult, 0, len(railIDs))
+	successCount := 0
+
+	for _, railID := range railIDs {
+		if !jsonMode {
+			fmt.Printf("Simulating settlement of rail %s (state: %s)...\n", railID.String(), simulateBlock)
+		}
+
+		simulated, err := contract.SimulateSettleRail(ctx, cfg.RPCUrl, cfg.PaymentsAddr(), serviceProviderAddr, railID, untilEpoch, simulateBlock)
+		if err != nil {
+			if !jsonMode {
+				fmt.Printf("  ❌ Simulation failed: %v\n\n", err)
+			}
+			railResults = append(railResults, SimulateRailResult{RailID: railID.String(), Error: err.Error()})
+			continue
+		}
+
+		if !jsonMode {
+			fmt.Printf("  Projected settled up to: epoch %s\n", simulated.FinalSettledEpoch.String())
+			fmt.Printf("  Projected amount:        %s (%s)\n",
+				simulated.TotalSettledAmount.String(),
+				payments.FormatTokenAmount(simulated.TotalSettledAmount, decimals))
+			fmt.Printf("  Projected payee receives: %s (%s)\n",
+				simulated.TotalNetPayeeAmount.String(),
+				payments.FormatTokenAmount(simulated.TotalNetPayeeAmount, decimals))
+			fmt.Printf("  Projected commission:    %s (%s)\n",
+				simulated.TotalOperatorCommission.String(),
+				payments.FormatTokenAmount(simulated.TotalOperatorCommission, decimals))
+			fmt.Printf("  Gas used:                %d\n", simulated.GasUsed)
+			if simulated.Note != "" {
+				fmt.Printf("  Note:                    %s\n", simulated.Note)
+			}
+			fmt.Println()
+		}
+
+		railResults = append(railResults, SimulateRailResult{
+			RailID:                  railID.String(),
+			Simulated:               true,
+			TotalSettledAmount:      simulated.TotalSettledAmount.String(),
+			TotalNetPayeeAmount:     simulated.TotalNetPayeeAmount.String(),
+			TotalOperatorCommission: simulated.TotalOperatorCommission.String(),
+			FinalSettledEpoch:       simulated.FinalSettledEpoch.String(),
+			Note:                    simulated.Note,
+			GasUsed:                 simulated.GasUsed,
+		})
+		successCount++
+	}
+
+	if jsonMode {
+		_, err := output.Emit(SimulateResult{Rails: railResults, Succeeded: successCount, Total: len(railIDs)})
+		return err
+	}
+
+	fmt.Printf("Simulation complete: %d of %d rail(s) simulated successfully\n", successCount, len(railIDs))
+
+	return nil
+}
@@ -1,10 +1,11 @@
 package payments
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"time"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/spf13/cobra"
@@ -12,9 +13,52 @@ import (
 	"github.com/storacha/filecoin-services/go/bindings"
 	"github.com/storacha/filecoin-services/service-operator/internal/config"
 	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
 	"github.com/storacha/filecoin-services/service-operator/internal/payments"
+	"github.com/storacha/filecoin-services/service-operator/internal/registry"
 )
 
+// StatusRailResult is a single payment rail in the --output=json/yaml payload
+// for `payments status` and the `payments metrics` gauges.
+type StatusRailResult struct {
+	RailID           string `json:"railId" yaml:"railId"`
+	Terminated       bool   `json:"terminated" yaml:"terminated"`
+	StorageNode      string `json:"storageNode" yaml:"storageNode"`
+	ProviderName     string `json:"providerName,omitempty" yaml:"providerName,omitempty"`
+	ProviderID       string `json:"providerId,omitempty" yaml:"providerId,omitempty"`
+	Payer            string `json:"payer" yaml:"payer"`
+	PaymentRate      string `json:"paymentRate" yaml:"paymentRate"`
+	SettledUpToEpoch string `json:"settledUpToEpoch" yaml:"settledUpToEpoch"`
+	EndEpoch         string `json:"endEpoch,omitempty" yaml:"endEpoch,omitempty"`
+}
+
+// StatusResult is the --output=json/yaml payload for `payments status`, and
+// the source data CollectStatus hands to `payments metrics`.
+type StatusResult struct {
+	PaymentsContract string `json:"paymentsContract" yaml:"paymentsContract"`
+	TokenContract    string `json:"tokenContract" yaml:"tokenContract"`
+	ServiceContract  string `json:"serviceContract" yaml:"serviceContract"`
+	ContractOwner    string `json:"contractOwner" yaml:"contractOwner"`
+	SignerBackend    string `json:"signerBackend" yaml:"signerBackend"`
+
+	Funds          string `json:"funds" yaml:"funds"`
+	LockedFunds    string `json:"lockedFunds" yaml:"lockedFunds"`
+	AvailableFunds string `json:"availableFunds" yaml:"availableFunds"`
+
+	OperatorApproved      bool   `json:"operatorApproved" yaml:"operatorApproved"`
+	RateAllowance         string `json:"rateAllowance,omitempty" yaml:"rateAllowance,omitempty"`
+	RateUsage             string `json:"rateUsage,omitempty" yaml:"rateUsage,omitempty"`
+	RateAvailable         string `json:"rateAvailable,omitempty" yaml:"rateAvailable,omitempty"`
+	LockupAllowance       string `json:"lockupAllowance,omitempty" yaml:"lockupAllowance,omitempty"`
+	LockupUsage           string `json:"lockupUsage,omitempty" yaml:"lockupUsage,omitempty"`
+	LockupAvailable       string `json:"lockupAvailable,omitempty" yaml:"lockupAvailable,omitempty"`
+	MaxLockupPeriodEpochs string `json:"maxLockupPeriodEpochs,omitempty" yaml:"maxLockupPeriodEpochs,omitempty"`
+
+	Rails           []StatusRailResult `json:"rails" yaml:"rails"`
+	ActiveRails     int                `json:"activeRails" yaml:"activeRails"`
+	TerminatedRails int                `json:"terminatedRails" yaml:"terminatedRails"`
+}
+
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Display account balance, operator approval, and active payment rails",
@@ -42,121 +86,204 @@ Examples:
 	RunE: runStatus,
 }
 
-func runStatus(cobraCmd *cobra.Command, args []string) error {
-	ctx := cobraCmd.Context()
+var (
+	statusAccountIndex        uint32
+	statusProviderPageSize    int64
+	statusProviderConcurrency int
+	statusProviderCachePath   string
+	statusProviderCacheTTL    time.Duration
+)
 
-	cfg, err := config.Load()
-	if err != nil {
-		return err
+func init() {
+	statusCmd.Flags().Uint32Var(&statusAccountIndex, "account-index", 0, "HD account index to derive the payer address from (requires signers.payer.mnemonic or seed_file)")
+	statusCmd.Flags().Int64Var(&statusProviderPageSize, "provider-page-size", 0, "Provider IDs to request per registry page (0 uses the package default)")
+	statusCmd.Flags().IntVar(&statusProviderConcurrency, "provider-concurrency", 0, "Max concurrent provider-detail and rail lookups (0 uses the package default)")
+	statusCmd.Flags().StringVar(&statusProviderCachePath, "provider-cache", "", "Path to an on-disk cache of provider name/payee metadata (disabled if unset)")
+	statusCmd.Flags().DurationVar(&statusProviderCacheTTL, "provider-cache-ttl", 10*time.Minute, "How long a cached provider entry stays valid")
+}
+
+// enumerateRailsOptions builds an EnumerateRailsOptions from the
+// --provider-page-size/--provider-concurrency/--provider-cache flags,
+// opening the on-disk cache at --provider-cache if set.
+func enumerateRailsOptions(cachePath string, cacheTTL time.Duration, pageSize int64, concurrency int) (contract.EnumerateRailsOptions, error) {
+	opts := contract.EnumerateRailsOptions{Concurrency: concurrency}
+	if pageSize > 0 {
+		opts.PageSize = big.NewInt(pageSize)
 	}
+	if cachePath != "" {
+		cache, err := registry.OpenCache(cachePath, cacheTTL)
+		if err != nil {
+			return opts, fmt.Errorf("opening provider cache: %w", err)
+		}
+		opts.Cache = cache
+	}
+	return opts, nil
+}
 
+// CollectStatus queries the chain for everything `payments status` reports -
+// account balance, operator allowances, and every rail across every
+// registered storage node - at the payer account derived at accountIndex.
+// It's factored out of runStatus so `payments metrics` can reuse the same
+// collection logic to populate its Prometheus gauges.
+func CollectStatus(ctx context.Context, cfg *config.Config, accountIndex uint32, railOpts contract.EnumerateRailsOptions) (*StatusResult, error) {
 	client, err := ethclient.Dial(cfg.RPCUrl)
 	if err != nil {
-		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+		return nil, fmt.Errorf("connecting to RPC endpoint: %w", err)
 	}
 	defer client.Close()
 
 	// Create signer manager and load payer's private key to get address
 	signerManager := contract.NewSignerManager(cfg)
-	privateKey, err := signerManager.LoadPayerSigner()
+	privateKey, err := signerManager.LoadPayerSignerAtIndex(accountIndex)
 	if err != nil {
-		return fmt.Errorf("loading payer signer: %w", err)
+		return nil, fmt.Errorf("loading payer signer: %w", err)
 	}
 
 	contractOwnerAddr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	signerBackend := cfg.Signers["payer"].ResolvedBackend()
 
-	// Query ServiceProviderRegistry to get all registered providers
-	registry, err := bindings.NewServiceProviderRegistry(cfg.ServiceRegistryAddr(), client)
+	activeRails, err := contract.EnumerateActiveProviderRails(ctx, cfg.RPCUrl, cfg.ServiceRegistryAddr(), cfg.PaymentsAddr(), cfg.TokenAddr(), railOpts)
 	if err != nil {
-		return fmt.Errorf("creating registry binding: %w", err)
+		return nil, fmt.Errorf("enumerating active provider rails: %w", err)
 	}
 
-	// Get all active providers with a large limit to get all of them
-	providersResult, err := registry.GetAllActiveProviders(nil, big.NewInt(0), big.NewInt(1000))
+	paymentsContract, err := bindings.NewPayments(cfg.PaymentsAddr(), client)
 	if err != nil {
-		return fmt.Errorf("querying active providers: %w", err)
+		return nil, fmt.Errorf("creating payments contract binding: %w", err)
 	}
 
-	// Get full provider details
-	type ProviderDetail struct {
-		Name       string
-		ProviderId *big.Int
+	// Query account information
+	accountInfo, err := paymentsContract.Accounts(nil, cfg.TokenAddr(), contractOwnerAddr)
+	if err != nil {
+		return nil, fmt.Errorf("querying account information: %w", err)
 	}
-	var storageNodePayees []common.Address
-	providerDetails := make(map[common.Address]*ProviderDetail)
 
-	if len(providersResult.ProviderIds) > 0 {
-		providersInfo, err := registry.GetProvidersByIds(nil, providersResult.ProviderIds)
-		if err != nil {
-			return fmt.Errorf("getting provider details: %w", err)
-		}
+	// Query operator approval information
+	operatorInfo, err := paymentsContract.OperatorApprovals(nil, cfg.TokenAddr(), contractOwnerAddr, cfg.ServiceAddr())
+	if err != nil {
+		return nil, fmt.Errorf("querying operator approval: %w", err)
+	}
 
-		for i, providerView := range providersInfo.ProviderInfos {
-			if !providersInfo.ValidIds[i] || !providerView.Info.IsActive {
-				continue
-			}
-			payeeAddr := providerView.Info.Payee
-			storageNodePayees = append(storageNodePayees, payeeAddr)
-			providerDetails[payeeAddr] = &ProviderDetail{
-				Name:       providerView.Info.Name,
-				ProviderId: providerView.ProviderId,
-			}
+	availableFunds := new(big.Int).Sub(accountInfo.Funds, accountInfo.LockupCurrent)
+	rateAvailable := new(big.Int).Sub(operatorInfo.RateAllowance, operatorInfo.RateUsage)
+	lockupAvailable := new(big.Int).Sub(operatorInfo.LockupAllowance, operatorInfo.LockupUsage)
+
+	activeCount := 0
+	terminatedCount := 0
+
+	railResults := make([]StatusRailResult, 0, len(activeRails))
+	for _, rail := range activeRails {
+		r := StatusRailResult{
+			RailID:           rail.RailInfo.RailID.String(),
+			Terminated:       rail.RailInfo.IsTerminated,
+			StorageNode:      rail.RailInfo.To.Hex(),
+			ProviderName:     rail.ProviderName,
+			Payer:            rail.RailInfo.From.Hex(),
+			PaymentRate:      rail.RailInfo.PaymentRate.String(),
+			SettledUpToEpoch: rail.RailInfo.SettledUpTo.String(),
+		}
+		if rail.ProviderID != nil {
+			r.ProviderID = rail.ProviderID.String()
 		}
+		if rail.RailInfo.IsTerminated {
+			r.EndEpoch = rail.RailInfo.EndEpoch.String()
+			terminatedCount++
+		} else {
+			activeCount++
+		}
+		railResults = append(railResults, r)
 	}
 
-	// Query token decimals
-	decimals, err := GetTokenDecimals(ctx, client, cfg.TokenAddr())
+	result := &StatusResult{
+		PaymentsContract: cfg.PaymentsContractAddress,
+		TokenContract:    cfg.TokenContractAddress,
+		ServiceContract:  cfg.ServiceContractAddress,
+		ContractOwner:    contractOwnerAddr.Hex(),
+		SignerBackend:    signerBackend,
+
+		Funds:          accountInfo.Funds.String(),
+		LockedFunds:    accountInfo.LockupCurrent.String(),
+		AvailableFunds: availableFunds.String(),
+
+		OperatorApproved: operatorInfo.IsApproved,
+
+		Rails:           railResults,
+		ActiveRails:     activeCount,
+		TerminatedRails: terminatedCount,
+	}
+	if operatorInfo.IsApproved {
+		result.RateAllowance = operatorInfo.RateAllowance.String()
+		result.RateUsage = operatorInfo.RateUsage.String()
+		result.RateAvailable = rateAvailable.String()
+		result.LockupAllowance = operatorInfo.LockupAllowance.String()
+		result.LockupUsage = operatorInfo.LockupUsage.String()
+		result.LockupAvailable = lockupAvailable.String()
+		result.MaxLockupPeriodEpochs = operatorInfo.MaxLockupPeriod.String()
+	}
+
+	return result, nil
+}
+
+func runStatus(cobraCmd *cobra.Command, args []string) error {
+	ctx := cobraCmd.Context()
+
+	cfg, err := config.Load()
 	if err != nil {
-		return fmt.Errorf("querying token decimals: %w", err)
+		return err
 	}
 
-	paymentsContract, err := bindings.NewPayments(cfg.PaymentsAddr(), client)
+	railOpts, err := enumerateRailsOptions(statusProviderCachePath, statusProviderCacheTTL, statusProviderPageSize, statusProviderConcurrency)
 	if err != nil {
-		return fmt.Errorf("creating payments contract binding: %w", err)
+		return err
 	}
 
-	// Query account information
-	accountInfo, err := paymentsContract.Accounts(nil, cfg.TokenAddr(), contractOwnerAddr)
+	result, err := CollectStatus(ctx, cfg, statusAccountIndex, railOpts)
 	if err != nil {
-		return fmt.Errorf("querying account information: %w", err)
+		return err
 	}
 
-	// Query operator approval information
-	operatorInfo, err := paymentsContract.OperatorApprovals(nil, cfg.TokenAddr(), contractOwnerAddr, cfg.ServiceAddr())
+	if emitted, err := output.Emit(result); emitted {
+		return err
+	}
+
+	// Query token decimals and storage node count only needed for the
+	// human-readable rendering below.
+	client, err := ethclient.Dial(cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+	defer client.Close()
+
+	decimals, err := GetTokenDecimals(ctx, client, cfg.TokenAddr())
 	if err != nil {
-		return fmt.Errorf("querying operator approval: %w", err)
+		return fmt.Errorf("querying token decimals: %w", err)
 	}
 
+	fundsBig, _ := new(big.Int).SetString(result.Funds, 10)
+	lockedBig, _ := new(big.Int).SetString(result.LockedFunds, 10)
+	availableBig, _ := new(big.Int).SetString(result.AvailableFunds, 10)
+
 	// Display results
 	fmt.Println("Payments Account Status")
 	fmt.Println("=======================")
 	fmt.Println()
 	fmt.Println("Configuration:")
-	fmt.Printf("  Payments contract:      %s\n", cfg.PaymentsContractAddress)
-	fmt.Printf("  Token contract:         %s\n", cfg.TokenContractAddress)
-	fmt.Printf("  Service contract:       %s\n", cfg.ServiceContractAddress)
-	fmt.Printf("  Contract owner:         %s\n", contractOwnerAddr.Hex())
-	fmt.Printf("  Registered storage nodes: %d\n", len(storageNodePayees))
+	fmt.Printf("  Payments contract:      %s\n", result.PaymentsContract)
+	fmt.Printf("  Token contract:         %s\n", result.TokenContract)
+	fmt.Printf("  Service contract:       %s\n", result.ServiceContract)
+	fmt.Printf("  Contract owner:         %s\n", result.ContractOwner)
+	fmt.Printf("  Signer backend:         %s\n", result.SignerBackend)
 	fmt.Printf("  RPC URL:                %s\n", cfg.RPCUrl)
 	fmt.Println()
 
 	fmt.Println("Account Balance:")
-	fmt.Printf("  Total funds:            %s (%s)\n",
-		accountInfo.Funds.String(),
-		payments.FormatTokenAmount(accountInfo.Funds, decimals))
-	fmt.Printf("  Locked funds:           %s (%s)\n",
-		accountInfo.LockupCurrent.String(),
-		payments.FormatTokenAmount(accountInfo.LockupCurrent, decimals))
-
-	// Calculate available funds
-	availableFunds := new(big.Int).Sub(accountInfo.Funds, accountInfo.LockupCurrent)
-	fmt.Printf("  Available funds:        %s (%s)\n",
-		availableFunds.String(),
-		payments.FormatTokenAmount(availableFunds, decimals))
+	fmt.Printf("  Total funds:            %s (%s)\n", result.Funds, payments.FormatTokenAmount(fundsBig, decimals))
+	fmt.Printf("  Locked funds:           %s (%s)\n", result.LockedFunds, payments.FormatTokenAmount(lockedBig, decimals))
+	fmt.Printf("  Available funds:        %s (%s)\n", result.AvailableFunds, payments.FormatTokenAmount(availableBig, decimals))
 	fmt.Println()
 
 	fmt.Println("Operator Approval Status:")
-	if !operatorInfo.IsApproved {
+	if !result.OperatorApproved {
 		fmt.Println("  Status:                 ❌ Not approved")
 		fmt.Println()
 		fmt.Println("Next steps:")
@@ -166,124 +293,73 @@ func runStatus(cobraCmd *cobra.Command, args []string) error {
 		fmt.Println("       --lockup-allowance <value> \\")
 		fmt.Println("       --max-lockup-period <value>")
 	} else {
+		rateAllowance, _ := new(big.Int).SetString(result.RateAllowance, 10)
+		rateUsage, _ := new(big.Int).SetString(result.RateUsage, 10)
+		rateAvailable, _ := new(big.Int).SetString(result.RateAvailable, 10)
+		lockupAllowance, _ := new(big.Int).SetString(result.LockupAllowance, 10)
+		lockupUsage, _ := new(big.Int).SetString(result.LockupUsage, 10)
+		lockupAvailable, _ := new(big.Int).SetString(result.LockupAvailable, 10)
+		maxLockupPeriod, _ := new(big.Int).SetString(result.MaxLockupPeriodEpochs, 10)
+
 		fmt.Println("  Status:                 ✓ Approved")
 		fmt.Println()
 		fmt.Println("  Rate Allowance:")
-		fmt.Printf("    Total allowance:      %s/epoch (%s/epoch)\n",
-			operatorInfo.RateAllowance.String(),
-			payments.FormatTokenAmount(operatorInfo.RateAllowance, decimals))
-		fmt.Printf("    Currently used:       %s/epoch (%s/epoch)\n",
-			operatorInfo.RateUsage.String(),
-			payments.FormatTokenAmount(operatorInfo.RateUsage, decimals))
-		rateAvailable := new(big.Int).Sub(operatorInfo.RateAllowance, operatorInfo.RateUsage)
-		fmt.Printf("    Available:            %s/epoch (%s/epoch)\n",
-			rateAvailable.String(),
-			payments.FormatTokenAmount(rateAvailable, decimals))
+		fmt.Printf("    Total allowance:      %s/epoch (%s/epoch)\n", result.RateAllowance, payments.FormatTokenAmount(rateAllowance, decimals))
+		fmt.Printf("    Currently used:       %s/epoch (%s/epoch)\n", result.RateUsage, payments.FormatTokenAmount(rateUsage, decimals))
+		fmt.Printf("    Available:            %s/epoch (%s/epoch)\n", result.RateAvailable, payments.FormatTokenAmount(rateAvailable, decimals))
 		fmt.Println()
 
 		fmt.Println("  Lockup Allowance:")
-		fmt.Printf("    Total allowance:      %s (%s)\n",
-			operatorInfo.LockupAllowance.String(),
-			payments.FormatTokenAmount(operatorInfo.LockupAllowance, decimals))
-		fmt.Printf("    Currently used:       %s (%s)\n",
-			operatorInfo.LockupUsage.String(),
-			payments.FormatTokenAmount(operatorInfo.LockupUsage, decimals))
-		lockupAvailable := new(big.Int).Sub(operatorInfo.LockupAllowance, operatorInfo.LockupUsage)
-		fmt.Printf("    Available:            %s (%s)\n",
-			lockupAvailable.String(),
-			payments.FormatTokenAmount(lockupAvailable, decimals))
+		fmt.Printf("    Total allowance:      %s (%s)\n", result.LockupAllowance, payments.FormatTokenAmount(lockupAllowance, decimals))
+		fmt.Printf("    Currently used:       %s (%s)\n", result.LockupUsage, payments.FormatTokenAmount(lockupUsage, decimals))
+		fmt.Printf("    Available:            %s (%s)\n", result.LockupAvailable, payments.FormatTokenAmount(lockupAvailable, decimals))
 		fmt.Println()
 
-		fmt.Printf("  Max Lockup Period:      %s epochs (%d days)\n",
-			operatorInfo.MaxLockupPeriod.String(),
-			operatorInfo.MaxLockupPeriod.Int64()/2880)
+		fmt.Printf("  Max Lockup Period:      %s epochs (%d days)\n", result.MaxLockupPeriodEpochs, maxLockupPeriod.Int64()/2880)
 	}
 
-	// Query active payment rails across all storage nodes
+	// Display active payment rails across all storage nodes
 	fmt.Println()
 	fmt.Println("Active Payment Rails:")
 
-	if len(storageNodePayees) == 0 {
-		fmt.Println("  No storage nodes registered.")
+	if len(result.Rails) == 0 {
+		fmt.Println("  No active payment rails found.")
 		fmt.Println()
-		fmt.Println("  Register storage providers using: service-operator provider register")
+		fmt.Println("  Payment rails are created when clients start using your storage service,")
+		fmt.Println("  or register storage providers using: service-operator provider register")
 	} else {
-		// Aggregate rails from all storage nodes
-		type RailWithProvider struct {
-			RailInfo *contract.RailInfo
-			Provider *ProviderDetail
-		}
-
-		var allRails []RailWithProvider
-		activeCount := 0
-		terminatedCount := 0
+		fmt.Printf("  Total rails: %d (Active: %d, Terminated: %d)\n", len(result.Rails), result.ActiveRails, result.TerminatedRails)
+		fmt.Println()
 
-		for _, payeeAddr := range storageNodePayees {
-			railSummaries, err := contract.QueryRailsForPayee(ctx, cfg.RPCUrl, cfg.PaymentsAddr(), payeeAddr, cfg.TokenAddr())
-			if err != nil {
-				fmt.Printf("  Warning: Error querying rails for payee %s: %v\n", payeeAddr.Hex(), err)
-				continue
+		for i, rail := range result.Rails {
+			status := "Active"
+			if rail.Terminated {
+				status = "Terminated"
 			}
 
-			for _, summary := range railSummaries {
-				railInfo, err := contract.QueryRailInfo(ctx, cfg.RPCUrl, cfg.PaymentsAddr(), summary.RailId)
-				if err != nil {
-					fmt.Printf("  Warning: Error querying rail %s: %v\n", summary.RailId.String(), err)
-					continue
-				}
-
-				allRails = append(allRails, RailWithProvider{
-					RailInfo: railInfo,
-					Provider: providerDetails[payeeAddr],
-				})
-
-				if railInfo.IsTerminated {
-					terminatedCount++
-				} else {
-					activeCount++
-				}
+			fmt.Printf("  Rail #%d:\n", i+1)
+			fmt.Printf("    Rail ID:              %s\n", rail.RailID)
+			fmt.Printf("    Status:               %s\n", status)
+			fmt.Printf("    Storage node:         %s\n", rail.StorageNode)
+			if rail.ProviderName != "" {
+				fmt.Printf("    Provider name:        %s\n", rail.ProviderName)
+				fmt.Printf("    Provider ID:          %s\n", rail.ProviderID)
 			}
-		}
-
-		if len(allRails) == 0 {
-			fmt.Println("  No active payment rails found.")
-			fmt.Println()
-			fmt.Println("  Payment rails are created when clients start using your storage service.")
-		} else {
-			fmt.Printf("  Total rails: %d (Active: %d, Terminated: %d)\n", len(allRails), activeCount, terminatedCount)
-			fmt.Println()
-
-			for i, rail := range allRails {
-				status := "Active"
-				if rail.RailInfo.IsTerminated {
-					status = "Terminated"
-				}
-
-				fmt.Printf("  Rail #%d:\n", i+1)
-				fmt.Printf("    Rail ID:              %s\n", rail.RailInfo.RailID.String())
-				fmt.Printf("    Status:               %s\n", status)
-				fmt.Printf("    Storage node:         %s\n", rail.RailInfo.To.Hex())
-				if rail.Provider != nil {
-					fmt.Printf("    Provider name:        %s\n", rail.Provider.Name)
-					fmt.Printf("    Provider ID:          %s\n", rail.Provider.ProviderId.String())
-				}
-				fmt.Printf("    Payer:                %s\n", rail.RailInfo.From.Hex())
-				fmt.Printf("    Payment rate:         %s/epoch (%s/epoch)\n",
-					rail.RailInfo.PaymentRate.String(),
-					payments.FormatTokenAmount(rail.RailInfo.PaymentRate, decimals))
-				fmt.Printf("    Settled up to:        epoch %s\n", rail.RailInfo.SettledUpTo.String())
-				if rail.RailInfo.IsTerminated {
-					fmt.Printf("    Terminated at:        epoch %s\n", rail.RailInfo.EndEpoch.String())
-				}
-				fmt.Println()
+			fmt.Printf("    Payer:                %s\n", rail.Payer)
+			paymentRate, _ := new(big.Int).SetString(rail.PaymentRate, 10)
+			fmt.Printf("    Payment rate:         %s/epoch (%s/epoch)\n", rail.PaymentRate, payments.FormatTokenAmount(paymentRate, decimals))
+			fmt.Printf("    Settled up to:        epoch %s\n", rail.SettledUpToEpoch)
+			if rail.Terminated {
+				fmt.Printf("    Terminated at:        epoch %s\n", rail.EndEpoch)
 			}
+			fmt.Println()
+		}
 
-			if activeCount > 0 {
-				fmt.Println("  To settle a rail:")
-				fmt.Println("    service-operator payments settle --rail-id <Rail ID>")
-				fmt.Println("  To settle all active rails:")
-				fmt.Println("    service-operator payments settle --all")
-			}
+		if result.ActiveRails > 0 {
+			fmt.Println("  To settle a rail:")
+			fmt.Println("    service-operator payments settle --rail-id <Rail ID>")
+			fmt.Println("  To settle all active rails:")
+			fmt.Println("    service-operator payments settle --all")
 		}
 	}
 
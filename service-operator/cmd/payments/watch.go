@@ -0,0 +1,175 @@
+package payments
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/registry"
+	"github.com/storacha/filecoin-services/service-operator/internal/watch"
+)
+
+var (
+	watchInterval                 time.Duration
+	watchSettleWhenLagEpochs      string
+	watchSettleWhenUnsettledValue string
+	watchMinAvailableFunds        string
+	watchMaxGasPrice              string
+	watchWebhookURL               string
+	watchAccountIndex             uint32
+	watchLogFormat                string
+	watchProviderPageSize         int64
+	watchProviderConcurrency      int
+	watchProviderCachePath        string
+	watchProviderCacheTTL         time.Duration
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Run a long-lived process that settles the payer's rails automatically",
+	Long: `Run a long-lived process that polls every active payment rail across every
+registered storage node (from the payer's side, unlike 'payments daemon' which settles on
+behalf of a service provider being paid) and settles a rail automatically once it crosses
+--settle-when-lag-epochs or --settle-when-unsettled-value.
+
+--min-available-funds skips settlement (emitting a "funds_low" event instead) once the
+payer's free balance would leave too little to cover it. --max-gas-price caps the gas price
+settlement transactions are signed with.
+
+Every settlement decision is emitted as a JSON event to stdout and, if --webhook-url is set,
+POSTed there too, so an external system can track settled/skipped/funds_low events without
+polling 'payments status' itself.
+
+Examples:
+  # Settle rails every 10 minutes once they have 2 hours of unsettled epochs
+  service-operator payments watch --interval 10m --settle-when-lag-epochs 7200
+
+  # Also skip settlement below a funds floor and cap gas price
+  service-operator payments watch --interval 10m --settle-when-lag-epochs 7200 \
+    --min-available-funds 5000000000000000000 --max-gas-price 50000000000`,
+	RunE: runWatch,
+}
+
+func init() {
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Minute, "How often to re-enumerate rails and check settlement thresholds")
+	watchCmd.Flags().StringVar(&watchSettleWhenLagEpochs, "settle-when-lag-epochs", "", "Settle a rail once it has this many unsettled epochs since its last settlement")
+	watchCmd.Flags().StringVar(&watchSettleWhenUnsettledValue, "settle-when-unsettled-value", "", "Settle a rail early once its projected settlement amount (token base units) reaches this value")
+	watchCmd.Flags().StringVar(&watchMinAvailableFunds, "min-available-funds", "", "Skip settlement and emit a funds_low event once the payer's free balance drops below this value (token base units)")
+	watchCmd.Flags().StringVar(&watchMaxGasPrice, "max-gas-price", "", "Cap the gas price settlement transactions are signed with (wei)")
+	watchCmd.Flags().StringVar(&watchWebhookURL, "webhook-url", "", "URL to POST each settlement event to, in addition to stdout")
+	watchCmd.Flags().Uint32Var(&watchAccountIndex, "account-index", 0, "HD account index to derive the payer signer from (requires signers.payer.mnemonic or seed_file)")
+	watchCmd.Flags().StringVar(&watchLogFormat, "log-format", "text", "Log format: text or json")
+	watchCmd.Flags().Int64Var(&watchProviderPageSize, "provider-page-size", 0, "Provider IDs to request per registry page (0 uses the package default)")
+	watchCmd.Flags().IntVar(&watchProviderConcurrency, "provider-concurrency", 0, "Max concurrent provider-detail and rail lookups (0 uses the package default)")
+	watchCmd.Flags().StringVar(&watchProviderCachePath, "provider-cache", "", "Path to an on-disk cache of provider name/payee metadata (disabled if unset)")
+	watchCmd.Flags().DurationVar(&watchProviderCacheTTL, "provider-cache-ttl", 10*time.Minute, "How long a cached provider entry stays valid")
+}
+
+func runWatch(cobraCmd *cobra.Command, args []string) error {
+	ctx, cancel := signal.NotifyContext(cobraCmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	logger, err := newLogger(watchLogFormat)
+	if err != nil {
+		return err
+	}
+
+	client, err := ethclient.DialContext(ctx, cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+
+	signerManager := contract.NewSignerManager(cfg)
+	txSigner, err := signerManager.LoadPayerTxSignerAtIndex(ctx, watchAccountIndex)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("loading payer signer: %w", err)
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("getting chain ID: %w", err)
+	}
+	client.Close()
+
+	lagEpochs, err := parseOptionalBigInt(watchSettleWhenLagEpochs, "--settle-when-lag-epochs")
+	if err != nil {
+		return err
+	}
+	unsettledValue, err := parseOptionalBigInt(watchSettleWhenUnsettledValue, "--settle-when-unsettled-value")
+	if err != nil {
+		return err
+	}
+	minAvailableFunds, err := parseOptionalBigInt(watchMinAvailableFunds, "--min-available-funds")
+	if err != nil {
+		return err
+	}
+	maxGasPrice, err := parseOptionalBigInt(watchMaxGasPrice, "--max-gas-price")
+	if err != nil {
+		return err
+	}
+
+	railOpts := contract.EnumerateRailsOptions{Concurrency: watchProviderConcurrency}
+	if watchProviderPageSize > 0 {
+		railOpts.PageSize = big.NewInt(watchProviderPageSize)
+	}
+	if watchProviderCachePath != "" {
+		cache, err := registry.OpenCache(watchProviderCachePath, watchProviderCacheTTL)
+		if err != nil {
+			return fmt.Errorf("opening provider cache: %w", err)
+		}
+		railOpts.Cache = cache
+	}
+
+	w := watch.New(watch.Config{
+		RPCUrl:                   cfg.RPCUrl,
+		PaymentsAddress:          cfg.PaymentsAddr(),
+		TokenAddress:             cfg.TokenAddr(),
+		ServiceRegistryAddress:   cfg.ServiceRegistryAddr(),
+		TxSigner:                 txSigner,
+		ChainID:                  chainID,
+		Interval:                 watchInterval,
+		SettleWhenLagEpochs:      lagEpochs,
+		SettleWhenUnsettledValue: unsettledValue,
+		MinAvailableFunds:        minAvailableFunds,
+		MaxGasPrice:              maxGasPrice,
+		WebhookURL:               watchWebhookURL,
+		RailOpts:                 railOpts,
+		Logger:                   logger,
+	})
+
+	logger.Info("starting rail watcher",
+		"payer", txSigner.Address().Hex(),
+		"interval", watchInterval.String(),
+	)
+
+	return w.Run(ctx)
+}
+
+// parseOptionalBigInt parses s as a base-10 big.Int, returning nil if s is
+// empty so callers can treat an unset flag as "threshold disabled" instead
+// of requiring a sentinel value.
+func parseOptionalBigInt(s, flagName string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s: %s", flagName, s)
+	}
+	return n, nil
+}
@@ -1,15 +1,52 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/spf13/cobra"
 
 	"github.com/storacha/filecoin-services/go/bindings"
 	"github.com/storacha/filecoin-services/service-operator/internal/config"
 	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
+)
+
+// ApproveResult is the --output=json payload for `provider approve`.
+type ApproveResult struct {
+	ProviderID      string `json:"providerId"`
+	TransactionHash string `json:"transactionHash"`
+	BlockNumber     uint64 `json:"blockNumber"`
+	GasUsed         uint64 `json:"gasUsed"`
+}
+
+// ApproveOfflineResult is the --output=json payload for
+// `provider approve --offline`.
+type ApproveOfflineResult struct {
+	ProviderID string `json:"providerId"`
+	File       string `json:"file"`
+	Action     string `json:"action"`
+	From       string `json:"from"`
+}
+
+// ApproveBatchResult is the --output=json payload for
+// `provider approve --batch`.
+type ApproveBatchResult struct {
+	Approved   []uint64 `json:"approved"`
+	Skipped    []uint64 `json:"skipped"`
+	Failed     []uint64 `json:"failed"`
+	Multicall3 bool     `json:"multicall3"`
+}
+
+var (
+	approveOffline bool
+	approveFrom    string
+	approveOutFile string
+	approveBatch   string
 )
 
 var approveCmd = &cobra.Command{
@@ -18,11 +55,27 @@ var approveCmd = &cobra.Command{
 	Long: `Approve a provider by their ID to allow them to create datasets in the FilecoinWarmStorageService.
 
 The provider must already be registered in the ServiceProviderRegistry before approval.
-Only the contract owner can approve providers.`,
-	Args: cobra.ExactArgs(1),
+Only the contract owner can approve providers.
+
+With --offline, the unsigned transaction is written to --out instead of being signed and
+broadcast, so the owner key never has to touch the machine talking to the RPC endpoint. Sign
+and broadcast it later with "service-operator tx sign" and "service-operator tx submit".
+
+With --batch <file>, <provider-id> is ignored and every provider ID listed in the YAML manifest
+is approved instead: already-approved IDs are skipped, and the rest are packed into a single
+Multicall3 transaction when Multicall3 is deployed on this chain, or approved one transaction at
+a time otherwise. Run "provider diff <file>" first to preview what --batch would do.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runApprove,
 }
 
+func init() {
+	approveCmd.Flags().BoolVar(&approveOffline, "offline", false, "Write an unsigned transaction envelope to --out instead of signing and broadcasting")
+	approveCmd.Flags().StringVar(&approveFrom, "from", "", "Address the transaction will be sent from (required with --offline, since no signer is loaded locally)")
+	approveCmd.Flags().StringVar(&approveOutFile, "out", "", "Path to write the offline transaction envelope (required with --offline)")
+	approveCmd.Flags().StringVar(&approveBatch, "batch", "", "Approve every provider ID in this YAML manifest instead of a single <provider-id>")
+}
+
 // TODO: room for improvement here, this method will return success even if:
 // 1. the provider is already approved
 // 2. the provider doesn't exist
@@ -35,15 +88,29 @@ func runApprove(cobraCmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	jsonMode := output.Selected() == output.JSON
+
+	if approveBatch != "" {
+		if approveOffline {
+			return fmt.Errorf("--batch cannot be combined with --offline")
+		}
+		return runApproveBatch(ctx, cfg, jsonMode)
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("accepts 1 arg(s), received %d (or use --batch <file>)", len(args))
+	}
 	providerID := new(big.Int)
 	if _, ok := providerID.SetString(args[0], 10); !ok {
 		return fmt.Errorf("invalid provider ID: %s (must be a valid number)", args[0])
 	}
 
-	fmt.Printf("Approving provider ID: %s\n", providerID.String())
-	fmt.Printf("Service Contract: %s\n", cfg.ServiceContractAddress)
-	fmt.Printf("RPC URL: %s\n", cfg.RPCUrl)
-	fmt.Println()
+	if !jsonMode {
+		fmt.Printf("Approving provider ID: %s\n", providerID.String())
+		fmt.Printf("Service Contract: %s\n", cfg.ServiceContractAddress)
+		fmt.Printf("RPC URL: %s\n", cfg.RPCUrl)
+		fmt.Println()
+	}
 
 	client, err := ethclient.Dial(cfg.RPCUrl)
 	if err != nil {
@@ -51,22 +118,29 @@ func runApprove(cobraCmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
-	// Create signer manager and load owner's private key
-	signerManager := contract.NewSignerManager(cfg)
-	privateKey, err := signerManager.LoadOwnerSigner()
+	if approveOffline {
+		return runApproveOffline(ctx, cfg, client, providerID, jsonMode)
+	}
+
+	contractInstance, err := bindings.NewFilecoinWarmStorageService(cfg.ServiceAddr(), client)
 	if err != nil {
-		return fmt.Errorf("loading owner signer: %w", err)
+		return fmt.Errorf("creating contract binding: %w", err)
 	}
 
-	auth, err := contract.CreateTransactor(ctx, client, privateKey)
+	// Create signer manager and load the owner signer, which may be backed by
+	// a local keystore, an external JSON-RPC signer, a Ledger, a KMS key, or
+	// Vault depending on signers.owner.backend.
+	signerManager := contract.NewSignerManager(cfg)
+	txSigner, err := signerManager.LoadOwnerTxSigner(ctx)
 	if err != nil {
-		return fmt.Errorf("creating transactor: %w", err)
+		return fmt.Errorf("loading owner signer: %w", err)
 	}
 
-	contractInstance, err := bindings.NewFilecoinWarmStorageService(cfg.ServiceAddr(), client)
+	chainID, err := client.ChainID(ctx)
 	if err != nil {
-		return fmt.Errorf("creating contract binding: %w", err)
+		return fmt.Errorf("getting chain ID: %w", err)
 	}
+	auth := contract.CreateTransactorFromSigner(ctx, txSigner, chainID)
 
 	tx, err := contractInstance.AddApprovedProvider(auth, providerID)
 	if err != nil {
@@ -83,6 +157,16 @@ func runApprove(cobraCmd *cobra.Command, args []string) error {
 		return fmt.Errorf("parsing event: %w", err)
 	}
 
+	if jsonMode {
+		_, err := output.Emit(ApproveResult{
+			ProviderID:      approvedID.String(),
+			TransactionHash: receipt.TxHash.Hex(),
+			BlockNumber:     receipt.BlockNumber.Uint64(),
+			GasUsed:         receipt.GasUsed,
+		})
+		return err
+	}
+
 	fmt.Println()
 	fmt.Printf("✓ Provider %s approved successfully!\n", approvedID.String())
 	fmt.Printf("Transaction: %s\n", receipt.TxHash.Hex())
@@ -91,3 +175,241 @@ func runApprove(cobraCmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runApproveOffline builds the AddApprovedProvider transaction without
+// signing or broadcasting it, and writes it to approveOutFile as a
+// TxEnvelope for a later "tx sign" / "tx submit".
+func runApproveOffline(ctx context.Context, cfg *config.Config, client *ethclient.Client, providerID *big.Int, jsonMode bool) error {
+	if approveFrom == "" {
+		return fmt.Errorf("--from is required with --offline")
+	}
+	if !common.IsHexAddress(approveFrom) {
+		return fmt.Errorf("invalid --from address: %s", approveFrom)
+	}
+	if approveOutFile == "" {
+		return fmt.Errorf("--out is required with --offline")
+	}
+	from := common.HexToAddress(approveFrom)
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("getting chain ID: %w", err)
+	}
+
+	contractInstance, err := bindings.NewFilecoinWarmStorageService(cfg.ServiceAddr(), client)
+	if err != nil {
+		return fmt.Errorf("creating contract binding: %w", err)
+	}
+
+	auth, captured := contract.CreateOfflineTransactor(ctx, from)
+	if _, err := contractInstance.AddApprovedProvider(auth, providerID); err != nil {
+		return fmt.Errorf("building AddApprovedProvider transaction: %w", err)
+	}
+
+	action := fmt.Sprintf("AddApprovedProvider(providerId=%s)", providerID.String())
+	env, err := contract.NewTxEnvelope(captured.Tx, chainID, from, action)
+	if err != nil {
+		return err
+	}
+	if err := contract.WriteTxEnvelope(approveOutFile, env); err != nil {
+		return err
+	}
+
+	if jsonMode {
+		_, err := output.Emit(ApproveOfflineResult{
+			ProviderID: providerID.String(),
+			File:       approveOutFile,
+			Action:     action,
+			From:       from.Hex(),
+		})
+		return err
+	}
+
+	fmt.Printf("Wrote unsigned transaction envelope to %s\n", approveOutFile)
+	fmt.Printf("Action: %s\n", action)
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Printf("  service-operator tx sign %s\n", approveOutFile)
+	fmt.Printf("  service-operator tx submit %s\n", approveOutFile)
+
+	return nil
+}
+
+// runApproveBatch approves every provider ID listed in approveBatch's
+// manifest that isn't already approved, preferring a single Multicall3
+// transaction over one AddApprovedProvider transaction per provider.
+func runApproveBatch(ctx context.Context, cfg *config.Config, jsonMode bool) error {
+	desired, err := parseDesiredProviders(approveBatch)
+	if err != nil {
+		return err
+	}
+
+	client, err := ethclient.Dial(cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+	defer client.Close()
+
+	viewContract, err := getViewContract(client, cfg.ServiceAddr())
+	if err != nil {
+		return fmt.Errorf("connecting to view contract: %w", err)
+	}
+	bindCtx := &bind.CallOpts{Context: ctx}
+
+	var toApprove, skipped []*big.Int
+	for _, id := range desired {
+		approved, err := viewContract.IsProviderApproved(bindCtx, id)
+		if err != nil {
+			return fmt.Errorf("checking approval status for provider %s: %w", id.String(), err)
+		}
+		if approved {
+			skipped = append(skipped, id)
+			continue
+		}
+		toApprove = append(toApprove, id)
+	}
+
+	result := ApproveBatchResult{Skipped: idsToUint64(skipped)}
+
+	if len(toApprove) == 0 {
+		if jsonMode {
+			_, err := output.Emit(result)
+			return err
+		}
+		fmt.Printf("Nothing to do: all %d provider(s) in %s are already approved.\n", len(skipped), approveBatch)
+		return nil
+	}
+
+	signerManager := contract.NewSignerManager(cfg)
+	txSigner, err := signerManager.LoadOwnerTxSigner(ctx)
+	if err != nil {
+		return fmt.Errorf("loading owner signer: %w", err)
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("getting chain ID: %w", err)
+	}
+
+	useMulticall, err := contract.IsMulticall3Deployed(ctx, client)
+	if err != nil {
+		return fmt.Errorf("checking for Multicall3: %w", err)
+	}
+	result.Multicall3 = useMulticall
+
+	if useMulticall {
+		if err := approveBatchMulticall(ctx, cfg, client, viewContract, txSigner, chainID, toApprove, &result); err != nil {
+			return err
+		}
+	} else {
+		approveBatchSequential(ctx, cfg, client, txSigner, chainID, toApprove, &result)
+	}
+
+	if jsonMode {
+		_, err := output.Emit(result)
+		return err
+	}
+
+	fmt.Printf("Approved (%d): %v\n", len(result.Approved), result.Approved)
+	fmt.Printf("Skipped, already approved (%d): %v\n", len(result.Skipped), result.Skipped)
+	if len(result.Failed) > 0 {
+		fmt.Printf("Failed (%d): %v\n", len(result.Failed), result.Failed)
+		return fmt.Errorf("%d provider(s) failed to approve", len(result.Failed))
+	}
+
+	return nil
+}
+
+// approveBatchMulticall builds one AddApprovedProvider call per provider via
+// an offline transactor (so no new ABI-encoding is needed beyond the
+// generated binding) and packs all of them into a single Multicall3
+// transaction, with AllowFailure set so one already-approved or reverting
+// provider doesn't sink the rest of the batch. SendMulticall3's per-call
+// success flags come from a pre-broadcast dry-run, so once the batch is
+// mined we re-check each provider's actual approval state on-chain via
+// viewContract - the same ground truth runApproveBatch used to build
+// toApprove - rather than trusting the simulation alone, since on-chain
+// state can shift between the dry-run and the mined block.
+func approveBatchMulticall(ctx context.Context, cfg *config.Config, client *ethclient.Client, viewContract *bindings.FilecoinWarmStorageServiceStateView, txSigner contract.TxSigner, chainID *big.Int, toApprove []*big.Int, result *ApproveBatchResult) error {
+	contractInstance, err := bindings.NewFilecoinWarmStorageService(cfg.ServiceAddr(), client)
+	if err != nil {
+		return fmt.Errorf("creating contract binding: %w", err)
+	}
+
+	calls := make([]contract.Multicall3Call, 0, len(toApprove))
+	for _, id := range toApprove {
+		auth, captured := contract.CreateOfflineTransactor(ctx, txSigner.Address())
+		if _, err := contractInstance.AddApprovedProvider(auth, id); err != nil {
+			return fmt.Errorf("building AddApprovedProvider transaction for provider %s: %w", id.String(), err)
+		}
+		calls = append(calls, contract.Multicall3Call{
+			Target:       *captured.Tx.To(),
+			AllowFailure: true,
+			CallData:     captured.Tx.Data(),
+		})
+	}
+
+	receipt, callResults, err := contract.SendMulticall3(ctx, client, txSigner, chainID, calls)
+	if err != nil {
+		return fmt.Errorf("sending Multicall3 batch: %w", err)
+	}
+	if receipt.Status == 0 {
+		return fmt.Errorf("Multicall3 batch transaction %s reverted", receipt.TxHash.Hex())
+	}
+
+	bindCtx := &bind.CallOpts{Context: ctx}
+	for i, id := range toApprove {
+		approved, err := viewContract.IsProviderApproved(bindCtx, id)
+		if err != nil {
+			return fmt.Errorf("checking post-mine approval status for provider %s: %w", id.String(), err)
+		}
+		if !approved {
+			if callResults[i].Success {
+				fmt.Printf("provider %s: AddApprovedProvider call simulated successfully but provider is not approved after Multicall3 batch %s\n", id.String(), receipt.TxHash.Hex())
+			} else {
+				fmt.Printf("provider %s: AddApprovedProvider call failed within Multicall3 batch %s\n", id.String(), receipt.TxHash.Hex())
+			}
+			result.Failed = append(result.Failed, id.Uint64())
+			continue
+		}
+		result.Approved = append(result.Approved, id.Uint64())
+	}
+	return nil
+}
+
+// approveBatchSequential approves each provider one transaction at a time,
+// recording which succeeded and which failed instead of aborting the batch
+// on the first error.
+func approveBatchSequential(ctx context.Context, cfg *config.Config, client *ethclient.Client, txSigner contract.TxSigner, chainID *big.Int, toApprove []*big.Int, result *ApproveBatchResult) {
+	contractInstance, err := bindings.NewFilecoinWarmStorageService(cfg.ServiceAddr(), client)
+	if err != nil {
+		for _, id := range toApprove {
+			fmt.Printf("provider %s: creating contract binding: %v\n", id.String(), err)
+		}
+		result.Failed = idsToUint64(toApprove)
+		return
+	}
+
+	auth := contract.CreateTransactorFromSigner(ctx, txSigner, chainID)
+	for _, id := range toApprove {
+		tx, err := contractInstance.AddApprovedProvider(auth, id)
+		if err != nil {
+			fmt.Printf("provider %s: calling AddApprovedProvider: %v\n", id.String(), err)
+			result.Failed = append(result.Failed, id.Uint64())
+			continue
+		}
+		if _, err := contract.WaitForTransaction(ctx, client, tx.Hash()); err != nil {
+			fmt.Printf("provider %s: waiting for transaction: %v\n", id.String(), err)
+			result.Failed = append(result.Failed, id.Uint64())
+			continue
+		}
+		result.Approved = append(result.Approved, id.Uint64())
+	}
+}
+
+func idsToUint64(ids []*big.Int) []uint64 {
+	out := make([]uint64, len(ids))
+	for i, id := range ids {
+		out[i] = id.Uint64()
+	}
+	return out
+}
@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
+)
+
+// describePageSize is how many providers describe pages through per
+// GetAllActiveProviders call when resolving a service-provider address to a
+// provider ID (the registry has no direct address lookup).
+const describePageSize = 100
+
+// ProductConfig is the subset of a provider's registered PDP product
+// configuration `describe` surfaces: what it serves, where, and for how
+// much. It mirrors the ProductConfig captured opaquely by `provider
+// snapshot`, but typed here since describe needs to print individual
+// fields rather than replay the config verbatim.
+type ProductConfig struct {
+	Capabilities        []string `json:"capabilities,omitempty"`
+	ServiceURL          string   `json:"serviceUrl,omitempty"`
+	MinPieceSizeInBytes string   `json:"minPieceSizeInBytes,omitempty"`
+	MaxPieceSizeInBytes string   `json:"maxPieceSizeInBytes,omitempty"`
+	PricePerEpoch       string   `json:"pricePerEpoch,omitempty"`
+}
+
+// DescribeResult is the --output=json/yaml payload for `provider describe`.
+type DescribeResult struct {
+	ID          uint64 `json:"id"`
+	Address     string `json:"address"`
+	Payee       string `json:"payee"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsActive    bool   `json:"isActive"`
+
+	IsApproved      bool `json:"isApproved"`
+	IsApprovedKnown bool `json:"isApprovedKnown"`
+
+	Product *ProductConfig `json:"product,omitempty"`
+
+	EscrowFunds      string `json:"escrowFunds,omitempty"`
+	EscrowLocked     string `json:"escrowLocked,omitempty"`
+	EscrowKnown      bool   `json:"escrowKnown"`
+
+	DataSetCount      int64 `json:"dataSetCount,omitempty"`
+	DataSetCountKnown bool  `json:"dataSetCountKnown"`
+}
+
+var describeCmd = &cobra.Command{
+	Use:   "describe <provider-id|address>",
+	Short: "Show a provider's full dossier: registration, product config, pricing, and balance",
+	Long: `Show everything about a single provider that "list" doesn't: its registration record,
+approval state, every registered PDP product configuration (capabilities, service URL, piece size
+bounds, price per epoch), the payee's current escrow balance in the Payments contract, and how
+many active data sets it holds in the PDPVerifier contract.
+
+The argument may be either a numeric provider ID or a 0x-prefixed service-provider address; an
+address is resolved to a provider ID by paging through the registry.
+
+Product config, escrow balance, and data set count each require their own contract address
+(service_contract_address, payments_contract_address/token_contract_address, and
+verifier_contract_address respectively) to be configured; any that aren't are reported as unknown
+rather than failing the whole command.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDescribe,
+}
+
+func runDescribe(cobraCmd *cobra.Command, args []string) error {
+	ctx := cobraCmd.Context()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.RPCUrl == "" {
+		return fmt.Errorf("rpc_url is required")
+	}
+	if cfg.ServiceRegistryContractAddress == "" {
+		return fmt.Errorf("service_registry_contract_address is required")
+	}
+
+	client, err := ethclient.Dial(cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+	defer client.Close()
+
+	registry, err := bindings.NewServiceProviderRegistry(cfg.ServiceRegistryAddr(), client)
+	if err != nil {
+		return fmt.Errorf("creating registry binding: %w", err)
+	}
+
+	bindCtx := &bind.CallOpts{Context: ctx}
+
+	providerID, err := resolveProviderID(bindCtx, registry, args[0])
+	if err != nil {
+		return err
+	}
+
+	providers, err := registry.GetProvidersByIds(bindCtx, []*big.Int{providerID})
+	if err != nil {
+		return fmt.Errorf("getting provider details: %w", err)
+	}
+	if len(providers.ValidIds) == 0 || !providers.ValidIds[0] {
+		return fmt.Errorf("no provider registered with ID %s", providerID.String())
+	}
+	info := providers.ProviderInfos[0]
+
+	result := DescribeResult{
+		ID:          providerID.Uint64(),
+		Address:     info.Info.ServiceProvider.Hex(),
+		Payee:       info.Info.Payee.Hex(),
+		Name:        info.Info.Name,
+		Description: info.Info.Description,
+		IsActive:    info.Info.IsActive,
+	}
+
+	var warnings []string
+
+	if cfg.ServiceContractAddress != "" {
+		viewContract, err := getViewContract(client, cfg.ServiceAddr())
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("could not connect to view contract (approval status will not be shown): %v", err))
+		} else {
+			approved, err := viewContract.IsProviderApproved(bindCtx, providerID)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("checking approval status: %v", err))
+			} else {
+				result.IsApproved = approved
+				result.IsApprovedKnown = true
+			}
+		}
+	}
+
+	if product, err := registry.GetPDPService(bindCtx, providerID); err != nil {
+		warnings = append(warnings, fmt.Sprintf("fetching PDP product config: %v", err))
+	} else if product != nil {
+		result.Product = &ProductConfig{
+			Capabilities:        product.Capabilities,
+			ServiceURL:          product.ServiceURL,
+			MinPieceSizeInBytes: product.MinPieceSizeInBytes.String(),
+			MaxPieceSizeInBytes: product.MaxPieceSizeInBytes.String(),
+			PricePerEpoch:       product.PricePerEpoch.String(),
+		}
+	}
+
+	if cfg.PaymentsContractAddress != "" && cfg.TokenContractAddress != "" {
+		paymentsContract, err := bindings.NewPayments(cfg.PaymentsAddr(), client)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("could not bind payments contract (escrow balance will not be shown): %v", err))
+		} else {
+			accountInfo, err := paymentsContract.Accounts(bindCtx, cfg.TokenAddr(), info.Info.Payee)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("querying escrow balance: %v", err))
+			} else {
+				result.EscrowFunds = accountInfo.Funds.String()
+				result.EscrowLocked = accountInfo.LockupCurrent.String()
+				result.EscrowKnown = true
+			}
+		}
+	}
+
+	if cfg.VerifierContractAddress != "" {
+		verifier, err := bindings.NewPDPVerifierCaller(cfg.VerifierAddr(), client)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("could not bind verifier contract (data set count will not be shown): %v", err))
+		} else {
+			count, err := verifier.GetActiveDataSetCount(bindCtx, info.Info.ServiceProvider)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("querying data set count: %v", err))
+			} else {
+				result.DataSetCount = count.Int64()
+				result.DataSetCountKnown = true
+			}
+		}
+	}
+
+	if emitted, err := output.Emit(result); err != nil {
+		return err
+	} else if emitted {
+		return nil
+	}
+
+	printDescribe(result)
+	for _, w := range warnings {
+		fmt.Fprintf(cobraCmd.ErrOrStderr(), "Warning: %s\n", w)
+	}
+
+	return nil
+}
+
+// resolveProviderID accepts either a decimal provider ID or a 0x-prefixed
+// service-provider address, paging through the registry to find the
+// matching ID in the latter case.
+func resolveProviderID(bindCtx *bind.CallOpts, registry *bindings.ServiceProviderRegistry, arg string) (*big.Int, error) {
+	if !common.IsHexAddress(arg) {
+		providerID := new(big.Int)
+		if _, ok := providerID.SetString(arg, 10); !ok {
+			return nil, fmt.Errorf("invalid provider ID or address: %s", arg)
+		}
+		return providerID, nil
+	}
+
+	target := common.HexToAddress(arg)
+	pageSize := big.NewInt(describePageSize)
+	for offset := big.NewInt(0); ; offset = new(big.Int).Add(offset, pageSize) {
+		page, err := registry.GetAllActiveProviders(bindCtx, offset, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("getting active providers at offset %s: %w", offset.String(), err)
+		}
+		if len(page.ProviderIds) == 0 {
+			break
+		}
+
+		details, err := registry.GetProvidersByIds(bindCtx, page.ProviderIds)
+		if err != nil {
+			return nil, fmt.Errorf("getting provider details at offset %s: %w", offset.String(), err)
+		}
+		for i, providerView := range details.ProviderInfos {
+			if !details.ValidIds[i] {
+				continue
+			}
+			if providerView.Info.ServiceProvider == target {
+				return providerView.ProviderId, nil
+			}
+		}
+
+		if !page.HasMore {
+			break
+		}
+	}
+
+	return nil, fmt.Errorf("no active provider found with address %s", arg)
+}
+
+func printDescribe(result DescribeResult) {
+	fmt.Printf("Provider %d\n", result.ID)
+	fmt.Printf("  Address:       %s\n", result.Address)
+	fmt.Printf("  Payee:         %s\n", result.Payee)
+	fmt.Printf("  Name:          %s\n", result.Name)
+	fmt.Printf("  Description:   %s\n", result.Description)
+	fmt.Printf("  Active:        %t\n", result.IsActive)
+	if result.IsApprovedKnown {
+		fmt.Printf("  Approved:      %t\n", result.IsApproved)
+	} else {
+		fmt.Printf("  Approved:      unknown\n")
+	}
+
+	fmt.Println()
+	if result.Product != nil {
+		fmt.Println("Product config:")
+		fmt.Printf("  Capabilities:  %v\n", result.Product.Capabilities)
+		fmt.Printf("  Service URL:   %s\n", result.Product.ServiceURL)
+		fmt.Printf("  Piece size:    %s - %s bytes\n", result.Product.MinPieceSizeInBytes, result.Product.MaxPieceSizeInBytes)
+		fmt.Printf("  Price/epoch:   %s\n", result.Product.PricePerEpoch)
+	} else {
+		fmt.Println("Product config: none registered")
+	}
+
+	fmt.Println()
+	if result.EscrowKnown {
+		fmt.Printf("Escrow funds:    %s\n", result.EscrowFunds)
+		fmt.Printf("Escrow locked:   %s\n", result.EscrowLocked)
+	} else {
+		fmt.Printf("Escrow funds:    unknown\n")
+	}
+
+	if result.DataSetCountKnown {
+		fmt.Printf("Data sets:       %d\n", result.DataSetCount)
+	} else {
+		fmt.Printf("Data sets:       unknown\n")
+	}
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
+)
+
+// DiffResult is the --output=json payload for `provider diff`.
+type DiffResult struct {
+	ToApprove     []uint64 `json:"toApprove"`
+	AlreadyDone   []uint64 `json:"alreadyDone"`
+	NotInManifest []uint64 `json:"notInManifest"`
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <file>",
+	Short: "Compare a declarative manifest of desired approved providers against on-chain state",
+	Long: `Read a YAML manifest listing the provider IDs that should be approved, and report how that
+compares to FilecoinWarmStorageService's current approval state:
+
+  toApprove     - in the manifest, not yet approved on-chain
+  alreadyDone   - in the manifest, already approved on-chain
+  notInManifest - approved on-chain, but not listed in the manifest
+
+diff never changes anything; it's meant to be reviewed before running
+"provider approve --batch <file>". notInManifest is reported for visibility only - this contract
+has no provider-removal method, so there's nothing diff or approve can do about it.
+
+Manifest format:
+
+  providers:
+    - 12
+    - 34`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDiff,
+}
+
+func runDiff(cobraCmd *cobra.Command, args []string) error {
+	ctx := cobraCmd.Context()
+	manifestPath := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.RPCUrl == "" {
+		return fmt.Errorf("rpc_url is required")
+	}
+	if cfg.ServiceRegistryContractAddress == "" {
+		return fmt.Errorf("service_registry_contract_address is required")
+	}
+	if cfg.ServiceContractAddress == "" {
+		return fmt.Errorf("service_contract_address is required")
+	}
+
+	desired, err := parseDesiredProviders(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	client, err := ethclient.Dial(cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+	defer client.Close()
+
+	viewContract, err := getViewContract(client, cfg.ServiceAddr())
+	if err != nil {
+		return fmt.Errorf("connecting to view contract: %w", err)
+	}
+	bindCtx := &bind.CallOpts{Context: ctx}
+
+	desiredSet := make(map[uint64]bool, len(desired))
+	result := DiffResult{}
+	for _, id := range desired {
+		desiredSet[id.Uint64()] = true
+		approved, err := viewContract.IsProviderApproved(bindCtx, id)
+		if err != nil {
+			return fmt.Errorf("checking approval status for provider %s: %w", id.String(), err)
+		}
+		if approved {
+			result.AlreadyDone = append(result.AlreadyDone, id.Uint64())
+		} else {
+			result.ToApprove = append(result.ToApprove, id.Uint64())
+		}
+	}
+
+	registry, err := bindings.NewServiceProviderRegistry(cfg.ServiceRegistryAddr(), client)
+	if err != nil {
+		return fmt.Errorf("creating registry binding: %w", err)
+	}
+
+	// Page through every active provider (the same GetAllActiveProviders
+	// cursor "list" paginates with) to find approved providers the manifest
+	// doesn't mention, rather than trusting a single oversized page.
+	const pageSize = 100
+	for offset := int64(0); ; offset += pageSize {
+		page, err := registry.GetAllActiveProviders(bindCtx, big.NewInt(offset), big.NewInt(pageSize))
+		if err != nil {
+			return fmt.Errorf("getting active providers at offset %d: %w", offset, err)
+		}
+		for _, id := range page.ProviderIds {
+			if desiredSet[id.Uint64()] {
+				continue
+			}
+			approved, err := viewContract.IsProviderApproved(bindCtx, id)
+			if err != nil {
+				return fmt.Errorf("checking approval status for provider %s: %w", id.String(), err)
+			}
+			if approved {
+				result.NotInManifest = append(result.NotInManifest, id.Uint64())
+			}
+		}
+		if !page.HasMore {
+			break
+		}
+	}
+
+	if output.Selected() == output.JSON {
+		_, err := output.Emit(result)
+		return err
+	}
+
+	fmt.Printf("To approve (%d): %v\n", len(result.ToApprove), result.ToApprove)
+	fmt.Printf("Already approved (%d): %v\n", len(result.AlreadyDone), result.AlreadyDone)
+	fmt.Printf("Approved but not in manifest (%d): %v\n", len(result.NotInManifest), result.NotInManifest)
+
+	return nil
+}
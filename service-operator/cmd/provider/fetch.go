@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+)
+
+// fetchBatchSize is how many provider IDs a single GetProvidersByIds call
+// covers when the concurrent fetcher splits a page into batches.
+const fetchBatchSize = 25
+
+// fetchMaxAttempts is how many times a single RPC call is tried (the first
+// attempt plus retries) before it's counted as a failure.
+const fetchMaxAttempts = 3
+
+// fetchStats counts RPC calls, retries, and failures across a concurrent
+// provider fetch, for the summary line `list` prints once it's done. Safe
+// for concurrent use by fetch workers.
+type fetchStats struct {
+	mu sync.Mutex
+
+	batchCalls    int
+	approvalCalls int
+	productCalls  int
+	retries       int
+	failures      int
+}
+
+func (s *fetchStats) recordRetry() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retries++
+}
+
+func (s *fetchStats) recordFailure() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures++
+}
+
+func (s *fetchStats) recordCall(kind string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch kind {
+	case "batch":
+		s.batchCalls++
+	case "approval":
+		s.approvalCalls++
+	case "product":
+		s.productCalls++
+	}
+}
+
+func (s *fetchStats) summary() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fmt.Sprintf("RPC calls: %d batch, %d approval, %d product (%d retries, %d failed)",
+		s.batchCalls, s.approvalCalls, s.productCalls, s.retries, s.failures)
+}
+
+// retryApproval calls fn up to fetchMaxAttempts times, backing off
+// exponentially between attempts, each bounded by timeout.
+func retryApproval(ctx context.Context, timeout time.Duration, stats *fetchStats, fn func(ctx context.Context) (bool, error)) (bool, error) {
+	b := newBackoff()
+	var value bool
+	var err error
+	for attempt := 0; attempt < fetchMaxAttempts; attempt++ {
+		if attempt > 0 {
+			stats.recordRetry()
+			select {
+			case <-ctx.Done():
+				return false, ctx.Err()
+			case <-time.After(b.next()):
+			}
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, timeout)
+		value, err = fn(callCtx)
+		cancel()
+		if err == nil {
+			return value, nil
+		}
+	}
+	stats.recordFailure()
+	return value, err
+}
+
+// fetchProvidersConcurrently fetches and filters every provider in ids using
+// up to concurrency workers, each handling a fetchBatchSize-sized
+// GetProvidersByIds batch and then, per surviving provider, the approval and
+// (if needsProduct) PDP product config lookups. emit is called once per
+// surviving provider and must be safe for concurrent use; it's called from
+// worker goroutines in no particular order.
+func fetchProvidersConcurrently(
+	ctx context.Context,
+	registry *bindings.ServiceProviderRegistry,
+	viewContract *bindings.FilecoinWarmStorageServiceStateView,
+	ids []*big.Int,
+	concurrency int,
+	timeout time.Duration,
+	needsProduct bool,
+	filter func(ProviderInfo) bool,
+	emit func(ProviderInfo),
+	warn func(string),
+	stats *fetchStats,
+) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var batches [][]*big.Int
+	for i := 0; i < len(ids); i += fetchBatchSize {
+		end := i + fetchBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batches = append(batches, ids[i:end])
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for _, batch := range batches {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(batch []*big.Int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fetchBatch(ctx, registry, viewContract, batch, timeout, needsProduct, filter, emit, warn, stats)
+		}(batch)
+	}
+
+	wg.Wait()
+}
+
+func fetchBatch(
+	ctx context.Context,
+	registry *bindings.ServiceProviderRegistry,
+	viewContract *bindings.FilecoinWarmStorageServiceStateView,
+	ids []*big.Int,
+	timeout time.Duration,
+	needsProduct bool,
+	filter func(ProviderInfo) bool,
+	emit func(ProviderInfo),
+	warn func(string),
+	stats *fetchStats,
+) {
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	details, err := registry.GetProvidersByIds(&bind.CallOpts{Context: callCtx}, ids)
+	cancel()
+
+	b := newBackoff()
+	for attempt := 1; err != nil && attempt < fetchMaxAttempts; attempt++ {
+		stats.recordRetry()
+		select {
+		case <-ctx.Done():
+			stats.recordCall("batch")
+			stats.recordFailure()
+			warn(fmt.Sprintf("fetching details for %d provider(s): %v", len(ids), ctx.Err()))
+			return
+		case <-time.After(b.next()):
+		}
+
+		callCtx, cancel = context.WithTimeout(ctx, timeout)
+		details, err = registry.GetProvidersByIds(&bind.CallOpts{Context: callCtx}, ids)
+		cancel()
+	}
+	stats.recordCall("batch")
+	if err != nil {
+		stats.recordFailure()
+		warn(fmt.Sprintf("fetching details for %d provider(s): %v", len(ids), err))
+		return
+	}
+
+	for i, providerView := range details.ProviderInfos {
+		if !details.ValidIds[i] {
+			continue
+		}
+
+		info := ProviderInfo{
+			ID:          providerView.ProviderId.Uint64(),
+			Address:     providerView.Info.ServiceProvider.Hex(),
+			Payee:       providerView.Info.Payee.Hex(),
+			Name:        providerView.Info.Name,
+			Description: providerView.Info.Description,
+			IsActive:    providerView.Info.IsActive,
+		}
+
+		if viewContract != nil {
+			approved, err := retryApproval(ctx, timeout, stats, func(callCtx context.Context) (bool, error) {
+				return viewContract.IsProviderApproved(&bind.CallOpts{Context: callCtx}, providerView.ProviderId)
+			})
+			stats.recordCall("approval")
+			if err != nil {
+				warn(fmt.Sprintf("checking approval for provider %d: %v", info.ID, err))
+			} else {
+				info.IsApproved = approved
+			}
+		}
+
+		if needsProduct {
+			fetchProduct(ctx, registry, providerView.ProviderId, timeout, stats, warn, &info)
+		}
+
+		if filter(info) {
+			emit(info)
+		}
+	}
+}
+
+// fetchProduct fetches a single provider's PDP product config (with the same
+// manual retry-and-backoff shape fetchBatch uses, since the registry's
+// return type - like GetProvidersByIds' - has no generated binding to name
+// in a shared helper signature), and populates info.Product on success.
+func fetchProduct(ctx context.Context, registry *bindings.ServiceProviderRegistry, providerID *big.Int, timeout time.Duration, stats *fetchStats, warn func(string), info *ProviderInfo) {
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	product, err := registry.GetPDPService(&bind.CallOpts{Context: callCtx}, providerID)
+	cancel()
+
+	b := newBackoff()
+	for attempt := 1; err != nil && attempt < fetchMaxAttempts; attempt++ {
+		stats.recordRetry()
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-time.After(b.next()):
+			callCtx, cancel = context.WithTimeout(ctx, timeout)
+			product, err = registry.GetPDPService(&bind.CallOpts{Context: callCtx}, providerID)
+			cancel()
+		}
+	}
+	stats.recordCall("product")
+	if err != nil {
+		stats.recordFailure()
+		warn(fmt.Sprintf("fetching PDP product config for provider %d: %v", info.ID, err))
+		return
+	}
+	if product != nil {
+		info.Product = &ProductConfig{
+			Capabilities:        product.Capabilities,
+			ServiceURL:          product.ServiceURL,
+			MinPieceSizeInBytes: product.MinPieceSizeInBytes.String(),
+			MaxPieceSizeInBytes: product.MaxPieceSizeInBytes.String(),
+			PricePerEpoch:       product.PricePerEpoch.String(),
+		}
+	}
+}
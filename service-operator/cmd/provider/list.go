@@ -1,10 +1,15 @@
 package provider
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"path"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -16,10 +21,22 @@ import (
 )
 
 var (
-	listLimit        uint64
-	listOffset       uint64
-	listShowInactive bool
-	listFormat       string
+	listLimit           uint64
+	listOffset          uint64
+	listShowInactive    bool
+	listFormat          string
+	listApproved        bool
+	listPending         bool
+	listNameGlob        string
+	listPayee           string
+	listServiceProvider string
+	listProduct         string
+	listMinPrice        string
+	listMaxPrice        string
+	listSort            string
+	listReverse         bool
+	listConcurrency     int
+	listTimeout         time.Duration
 )
 
 var listCmd = &cobra.Command{
@@ -28,7 +45,24 @@ var listCmd = &cobra.Command{
 	Long: `List service providers registered in the ServiceProviderRegistry.
 
 By default, only active providers are shown. Use --show-inactive to include inactive providers.
-Results can be paginated using --offset and --limit flags.`,
+Results can be paginated using --offset and --limit flags.
+
+Results can be filtered by --approved/--pending (requires service_contract_address), --name-glob
+(shell-style glob against the provider's name), --payee and --service-provider (exact address
+match), and --product/--min-price/--max-price (evaluated against the provider's PDP product
+config, which requires service_contract_address to fetch). --product/--min-price/--max-price are
+only fetched for providers that survive the cheaper filters first, to keep RPC usage proportional
+to what's actually being filtered on.
+
+Use --sort=id|name|price|active (--reverse to flip it) to order the page, and --format=table|
+json|ndjson|csv to render it - ndjson in particular is meant for piping into jq when scripting
+over many providers.
+
+The approval and (when needed) product config lookups for providers surviving the cheap filters
+run concurrently, up to --concurrency at a time, each RPC call bounded by --timeout and retried
+with backoff on failure; a one-line RPC call summary is printed to stderr once the page is ready.
+With --format=ndjson results stream out as soon as each is fetched rather than waiting for the
+whole page, since --sort has no meaning until every row is in hand anyway.`,
 	Args: cobra.NoArgs,
 	RunE: runList,
 }
@@ -66,7 +100,19 @@ func init() {
 	listCmd.Flags().Uint64Var(&listLimit, "limit", 50, "Maximum number of providers to display")
 	listCmd.Flags().Uint64Var(&listOffset, "offset", 0, "Starting offset for pagination")
 	listCmd.Flags().BoolVar(&listShowInactive, "show-inactive", false, "Include inactive providers")
-	listCmd.Flags().StringVar(&listFormat, "format", "table", "Output format: table or json")
+	listCmd.Flags().StringVar(&listFormat, "format", "table", "Output format: table, json, ndjson, or csv")
+	listCmd.Flags().BoolVar(&listApproved, "approved", false, "Only show providers approved in FilecoinWarmStorageService")
+	listCmd.Flags().BoolVar(&listPending, "pending", false, "Only show providers not yet approved in FilecoinWarmStorageService")
+	listCmd.Flags().StringVar(&listNameGlob, "name-glob", "", "Only show providers whose name matches this shell-style glob")
+	listCmd.Flags().StringVar(&listPayee, "payee", "", "Only show the provider with this exact payee address")
+	listCmd.Flags().StringVar(&listServiceProvider, "service-provider", "", "Only show the provider with this exact service-provider address")
+	listCmd.Flags().StringVar(&listProduct, "product", "", "Only show providers whose PDP product config advertises this capability")
+	listCmd.Flags().StringVar(&listMinPrice, "min-price", "", "Only show providers whose PDP price per epoch is at least this amount")
+	listCmd.Flags().StringVar(&listMaxPrice, "max-price", "", "Only show providers whose PDP price per epoch is at most this amount")
+	listCmd.Flags().StringVar(&listSort, "sort", "id", "Sort by id, name, price, or active")
+	listCmd.Flags().BoolVar(&listReverse, "reverse", false, "Reverse the sort order")
+	listCmd.Flags().IntVar(&listConcurrency, "concurrency", 8, "Maximum number of providers to fetch approval/product details for at once")
+	listCmd.Flags().DurationVar(&listTimeout, "timeout", 30*time.Second, "Timeout for each approval/product config RPC call")
 }
 
 type ProviderInfo struct {
@@ -77,6 +123,11 @@ type ProviderInfo struct {
 	Description string `json:"description"`
 	IsActive    bool   `json:"isActive"`
 	IsApproved  bool   `json:"isApproved"`
+
+	// Product is only populated when a --product/--min-price/--max-price
+	// filter or --sort=price is requested, since fetching it costs an extra
+	// registry call per provider.
+	Product *ProductConfig `json:"product,omitempty"`
 }
 
 type ListResult struct {
@@ -101,8 +152,49 @@ func runList(cobraCmd *cobra.Command, args []string) error {
 		return fmt.Errorf("rpc_url is required")
 	}
 
-	if listFormat != "table" && listFormat != "json" {
-		return fmt.Errorf("invalid format: %s (must be 'table' or 'json')", listFormat)
+	switch listFormat {
+	case "table", "json", "ndjson", "csv":
+	default:
+		return fmt.Errorf("invalid format: %s (must be 'table', 'json', 'ndjson', or 'csv')", listFormat)
+	}
+
+	switch listSort {
+	case "id", "name", "price", "active":
+	default:
+		return fmt.Errorf("invalid --sort: %s (must be 'id', 'name', 'price', or 'active')", listSort)
+	}
+
+	if listApproved && listPending {
+		return fmt.Errorf("cannot specify both --approved and --pending")
+	}
+	if (listApproved || listPending) && cfg.ServiceContractAddress == "" {
+		return fmt.Errorf("--approved/--pending require service_contract_address (to reach the view contract)")
+	}
+
+	needsProduct := listProduct != "" || listMinPrice != "" || listMaxPrice != "" || listSort == "price"
+	if needsProduct && cfg.ServiceContractAddress == "" {
+		return fmt.Errorf("--product/--min-price/--max-price/--sort=price require service_contract_address (to reach the view contract)")
+	}
+
+	var minPrice, maxPrice *big.Int
+	if listMinPrice != "" {
+		minPrice = new(big.Int)
+		if _, ok := minPrice.SetString(listMinPrice, 10); !ok {
+			return fmt.Errorf("invalid --min-price: %s", listMinPrice)
+		}
+	}
+	if listMaxPrice != "" {
+		maxPrice = new(big.Int)
+		if _, ok := maxPrice.SetString(listMaxPrice, 10); !ok {
+			return fmt.Errorf("invalid --max-price: %s", listMaxPrice)
+		}
+	}
+
+	if listPayee != "" && !common.IsHexAddress(listPayee) {
+		return fmt.Errorf("invalid --payee address: %s", listPayee)
+	}
+	if listServiceProvider != "" && !common.IsHexAddress(listServiceProvider) {
+		return fmt.Errorf("invalid --service-provider address: %s", listServiceProvider)
 	}
 
 	client, err := ethclient.Dial(cfg.RPCUrl)
@@ -130,18 +222,15 @@ func runList(cobraCmd *cobra.Command, args []string) error {
 	}
 
 	if len(result.ProviderIds) == 0 {
-		if listFormat == "json" {
-			output, _ := json.MarshalIndent(ListResult{
-				Providers: []ProviderInfo{},
-				HasMore:   false,
-				Offset:    listOffset,
-				Limit:     listLimit,
-			}, "", "  ")
-			fmt.Println(string(output))
-		} else {
-			fmt.Println("No providers found.")
+		if listFormat == "ndjson" {
+			return nil
 		}
-		return nil
+		return renderList(cobraCmd, ListResult{
+			Providers: []ProviderInfo{},
+			HasMore:   false,
+			Offset:    listOffset,
+			Limit:     listLimit,
+		})
 	}
 
 	// Get full provider information
@@ -163,58 +252,229 @@ func runList(cobraCmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Convert to display format
-	providers := make([]ProviderInfo, 0)
+	// Apply the cheap filters (no extra RPC calls) before anything that needs
+	// a contract round-trip, so approval/product lookups are only spent on
+	// providers that could still make it into the result.
+	var candidates []*big.Int
 	for i, providerView := range providersResult.ProviderInfos {
 		if !providersResult.ValidIds[i] {
 			continue
 		}
-
-		// Skip inactive providers unless requested
 		if !listShowInactive && !providerView.Info.IsActive {
 			continue
 		}
-
-		// Check approval status if view contract is available
-		isApproved := false
-		if viewContract != nil {
-			approved, err := viewContract.IsProviderApproved(bindCtx, providerView.ProviderId)
+		if listNameGlob != "" {
+			matched, err := path.Match(listNameGlob, providerView.Info.Name)
 			if err != nil {
-				fmt.Fprintf(cobraCmd.ErrOrStderr(), "Error checking if provider %d is approved: %v\n", providerView.ProviderId.Uint64(), err)
-				isApproved = false
-			} else {
-				isApproved = approved
+				return fmt.Errorf("invalid --name-glob: %w", err)
+			}
+			if !matched {
+				continue
 			}
 		}
+		if listPayee != "" && providerView.Info.Payee != common.HexToAddress(listPayee) {
+			continue
+		}
+		if listServiceProvider != "" && providerView.Info.ServiceProvider != common.HexToAddress(listServiceProvider) {
+			continue
+		}
+		candidates = append(candidates, providerView.ProviderId)
+	}
 
-		providers = append(providers, ProviderInfo{
-			ID:          providerView.ProviderId.Uint64(),
-			Address:     providerView.Info.ServiceProvider.Hex(),
-			Payee:       providerView.Info.Payee.Hex(),
-			Name:        providerView.Info.Name,
-			Description: providerView.Info.Description,
-			IsActive:    providerView.Info.IsActive,
-			IsApproved:  isApproved,
-		})
+	filter := func(info ProviderInfo) bool {
+		if listApproved && !info.IsApproved {
+			return false
+		}
+		if listPending && info.IsApproved {
+			return false
+		}
+		if needsProduct {
+			if listProduct != "" && !hasCapability(info.Product, listProduct) {
+				return false
+			}
+			if (minPrice != nil || maxPrice != nil) && !priceInRange(info.Product, minPrice, maxPrice) {
+				return false
+			}
+		}
+		return true
 	}
 
-	// Display results
-	if listFormat == "json" {
-		output, err := json.MarshalIndent(ListResult{
-			Providers: providers,
-			HasMore:   result.HasMore,
-			Offset:    listOffset,
-			Limit:     listLimit,
-		}, "", "  ")
+	stats := &fetchStats{}
+	streaming := listFormat == "ndjson"
+
+	var mu sync.Mutex
+	var providers []ProviderInfo
+	var enc *json.Encoder
+	if streaming {
+		enc = json.NewEncoder(cobraCmd.OutOrStdout())
+	}
+
+	emit := func(info ProviderInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		if streaming {
+			if err := enc.Encode(info); err != nil {
+				fmt.Fprintf(cobraCmd.ErrOrStderr(), "Warning: encoding NDJSON for provider %d: %v\n", info.ID, err)
+			}
+			return
+		}
+		providers = append(providers, info)
+	}
+	warn := func(msg string) {
+		fmt.Fprintf(cobraCmd.ErrOrStderr(), "Warning: %s\n", msg)
+	}
+
+	fetchProvidersConcurrently(ctx, registry, viewContract, candidates, listConcurrency, listTimeout, needsProduct, filter, emit, warn, stats)
+
+	fmt.Fprintln(cobraCmd.ErrOrStderr(), stats.summary())
+
+	if streaming {
+		return nil
+	}
+
+	sortProviders(providers)
+
+	return renderList(cobraCmd, ListResult{
+		Providers: providers,
+		HasMore:   result.HasMore,
+		Offset:    listOffset,
+		Limit:     listLimit,
+	})
+}
+
+// hasCapability reports whether product advertises capability,
+// case-insensitively. A provider with no product config never matches.
+func hasCapability(product *ProductConfig, capability string) bool {
+	if product == nil {
+		return false
+	}
+	for _, c := range product.Capabilities {
+		if strings.EqualFold(c, capability) {
+			return true
+		}
+	}
+	return false
+}
+
+// priceInRange reports whether product's price per epoch falls within
+// [min, max] (either bound may be nil). A provider with no product config
+// never matches, since there's no price to compare against.
+func priceInRange(product *ProductConfig, min, max *big.Int) bool {
+	if product == nil {
+		return false
+	}
+	price, ok := new(big.Int).SetString(product.PricePerEpoch, 10)
+	if !ok {
+		return false
+	}
+	if min != nil && price.Cmp(min) < 0 {
+		return false
+	}
+	if max != nil && price.Cmp(max) > 0 {
+		return false
+	}
+	return true
+}
+
+// sortProviders orders providers in place by --sort, applying --reverse
+// afterward. Providers with no product config sort last under --sort=price.
+func sortProviders(providers []ProviderInfo) {
+	less := func(i, j int) bool {
+		switch listSort {
+		case "name":
+			return strings.ToLower(providers[i].Name) < strings.ToLower(providers[j].Name)
+		case "price":
+			pi, iok := providerPrice(providers[i])
+			pj, jok := providerPrice(providers[j])
+			if !iok {
+				return false
+			}
+			if !jok {
+				return true
+			}
+			return pi.Cmp(pj) < 0
+		case "active":
+			return !providers[i].IsActive && providers[j].IsActive
+		default:
+			return providers[i].ID < providers[j].ID
+		}
+	}
+	sort.SliceStable(providers, less)
+	if listReverse {
+		for i, j := 0, len(providers)-1; i < j; i, j = i+1, j-1 {
+			providers[i], providers[j] = providers[j], providers[i]
+		}
+	}
+}
+
+func providerPrice(p ProviderInfo) (*big.Int, bool) {
+	if p.Product == nil {
+		return nil, false
+	}
+	price, ok := new(big.Int).SetString(p.Product.PricePerEpoch, 10)
+	if !ok {
+		return nil, false
+	}
+	return price, true
+}
+
+// renderList writes result in the format selected by --format. --format=ndjson
+// is handled separately in runList, streaming rows as they're fetched rather
+// than waiting for the whole page, so it never reaches here.
+func renderList(cobraCmd *cobra.Command, result ListResult) error {
+	switch listFormat {
+	case "json":
+		data, err := json.MarshalIndent(result, "", "  ")
 		if err != nil {
 			return fmt.Errorf("marshaling JSON: %w", err)
 		}
-		fmt.Println(string(output))
-	} else {
-		displayTable(providers, result.HasMore)
+		fmt.Println(string(data))
+		return nil
+
+	case "csv":
+		return displayCSV(cobraCmd, result.Providers)
+
+	default:
+		displayTable(result.Providers, result.HasMore)
+		return nil
+	}
+}
+
+func displayCSV(cobraCmd *cobra.Command, providers []ProviderInfo) error {
+	w := csv.NewWriter(cobraCmd.OutOrStdout())
+	defer w.Flush()
+
+	header := []string{"id", "address", "payee", "name", "description", "active", "approved", "capabilities", "serviceUrl", "pricePerEpoch"}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("writing CSV header: %w", err)
+	}
+
+	for _, p := range providers {
+		var capabilities, serviceURL, price string
+		if p.Product != nil {
+			capabilities = strings.Join(p.Product.Capabilities, ";")
+			serviceURL = p.Product.ServiceURL
+			price = p.Product.PricePerEpoch
+		}
+
+		row := []string{
+			fmt.Sprintf("%d", p.ID),
+			p.Address,
+			p.Payee,
+			p.Name,
+			p.Description,
+			fmt.Sprintf("%t", p.IsActive),
+			fmt.Sprintf("%t", p.IsApproved),
+			capabilities,
+			serviceURL,
+			price,
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("writing CSV row: %w", err)
+		}
 	}
 
-	return nil
+	return w.Error()
 }
 
 func displayTable(providers []ProviderInfo, hasMore bool) {
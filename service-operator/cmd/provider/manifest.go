@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DesiredProviders is the declarative manifest format read by
+// `provider diff` and `provider approve --batch`: a flat YAML list of
+// provider IDs that should be approved.
+//
+// providers:
+//   - 12
+//   - 34
+type desiredProvidersManifest struct {
+	Providers []string `yaml:"providers"`
+}
+
+// parseDesiredProviders reads a --batch/diff manifest file and returns the
+// provider IDs it lists, in file order.
+func parseDesiredProviders(path string) ([]*big.Int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest %s: %w", path, err)
+	}
+
+	var manifest desiredProvidersManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing manifest %s: %w", path, err)
+	}
+	if len(manifest.Providers) == 0 {
+		return nil, fmt.Errorf("manifest %s lists no providers", path)
+	}
+
+	ids := make([]*big.Int, 0, len(manifest.Providers))
+	for _, raw := range manifest.Providers {
+		id := new(big.Int)
+		if _, ok := id.SetString(raw, 10); !ok {
+			return nil, fmt.Errorf("manifest %s: invalid provider ID: %s", path, raw)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,457 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
+)
+
+// policyApplyPageSize is how many providers policy apply pages through per
+// GetAllActiveProviders call while walking the registry.
+const policyApplyPageSize = 100
+
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Manage the local approval policy evaluated by \"provider policy apply\"",
+	Long: `Manage the operator's locally configured approval policy: a name regex allow/deny pair, a
+payee allowlist, a minimum advertised PDP capacity, and a provider ID blocklist. The policy itself
+is stored under the "policy" key of the config file and doesn't touch the chain - use "policy
+apply" to actually approve or revoke providers based on it.`,
+}
+
+func init() {
+	policyCmd.AddCommand(policyListCmd)
+	policyCmd.AddCommand(policySetCmd)
+	policyCmd.AddCommand(policyApplyCmd)
+}
+
+var policyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print the locally configured approval policy",
+	Args:  cobra.NoArgs,
+	RunE:  runPolicyList,
+}
+
+func runPolicyList(cobraCmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	policy := cfg.Policy
+	if policy == nil {
+		policy = &config.ProviderPolicy{}
+	}
+
+	if emitted, err := output.Emit(policy); err != nil {
+		return err
+	} else if emitted {
+		return nil
+	}
+
+	fmt.Printf("Name allow:       %s\n", orNone(policy.NameAllow))
+	fmt.Printf("Name deny:        %s\n", orNone(policy.NameDeny))
+	fmt.Printf("Payee allowlist:  %v\n", policy.PayeeAllowlist)
+	fmt.Printf("Min capacity:     %s\n", orNone(policy.MinCapacityBytes))
+	fmt.Printf("Blocklist:        %v\n", policy.Blocklist)
+	return nil
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+var (
+	policySetNameAllow   string
+	policySetNameDeny    string
+	policySetPayeeAllow  []string
+	policySetMinCapacity string
+	policySetBlockAdd    []uint64
+	policySetBlockRemove []uint64
+	policySetClear       bool
+)
+
+var policySetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Update the local approval policy and write it back to the config file",
+	Long: `Update one or more fields of the local approval policy and persist the result to the
+config file in use (or ./service-operator.yaml if none was loaded). Only flags explicitly passed
+are changed; everything else is left as already configured. --blocklist-add/--blocklist-remove
+are additive/subtractive against the existing blocklist rather than replacing it outright.`,
+	Args: cobra.NoArgs,
+	RunE: runPolicySet,
+}
+
+func init() {
+	policySetCmd.Flags().StringVar(&policySetNameAllow, "name-allow", "", "Regular expression a provider's name must match to be approved")
+	policySetCmd.Flags().StringVar(&policySetNameDeny, "name-deny", "", "Regular expression that disqualifies a provider whose name matches it")
+	policySetCmd.Flags().StringSliceVar(&policySetPayeeAllow, "payee-allow", nil, "Replace the payee allowlist with this comma-separated list of addresses")
+	policySetCmd.Flags().StringVar(&policySetMinCapacity, "min-capacity", "", "Minimum PDP max piece size (bytes) a provider's product config must advertise")
+	policySetCmd.Flags().Uint64SliceVar(&policySetBlockAdd, "blocklist-add", nil, "Provider IDs to add to the blocklist")
+	policySetCmd.Flags().Uint64SliceVar(&policySetBlockRemove, "blocklist-remove", nil, "Provider IDs to remove from the blocklist")
+	policySetCmd.Flags().BoolVar(&policySetClear, "clear", false, "Reset the policy to empty before applying any other flags")
+}
+
+func runPolicySet(cobraCmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	policy := cfg.Policy
+	if policy == nil || policySetClear {
+		policy = &config.ProviderPolicy{}
+	}
+
+	flags := cobraCmd.Flags()
+	if flags.Changed("name-allow") {
+		policy.NameAllow = policySetNameAllow
+	}
+	if flags.Changed("name-deny") {
+		policy.NameDeny = policySetNameDeny
+	}
+	if flags.Changed("payee-allow") {
+		policy.PayeeAllowlist = policySetPayeeAllow
+	}
+	if flags.Changed("min-capacity") {
+		policy.MinCapacityBytes = policySetMinCapacity
+	}
+	for _, id := range policySetBlockAdd {
+		if !containsUint64(policy.Blocklist, id) {
+			policy.Blocklist = append(policy.Blocklist, id)
+		}
+	}
+	if len(policySetBlockRemove) > 0 {
+		policy.Blocklist = removeUint64s(policy.Blocklist, policySetBlockRemove)
+	}
+
+	viper.Set("policy.name_allow", policy.NameAllow)
+	viper.Set("policy.name_deny", policy.NameDeny)
+	viper.Set("policy.payee_allowlist", policy.PayeeAllowlist)
+	viper.Set("policy.min_capacity_bytes", policy.MinCapacityBytes)
+	viper.Set("policy.blocklist", policy.Blocklist)
+
+	if err := writePolicyConfig(); err != nil {
+		return err
+	}
+
+	if emitted, err := output.Emit(policy); err != nil {
+		return err
+	} else if emitted {
+		return nil
+	}
+
+	fmt.Println("Policy updated.")
+	return nil
+}
+
+// writePolicyConfig persists viper's in-memory configuration, including any
+// values set via viper.Set, back to the config file in use, falling back to
+// ./service-operator.yaml (loader.go's default) if no config file was read.
+func writePolicyConfig() error {
+	if viper.ConfigFileUsed() == "" {
+		return viper.WriteConfigAs("service-operator.yaml")
+	}
+	return viper.WriteConfig()
+}
+
+func containsUint64(haystack []uint64, needle uint64) bool {
+	for _, v := range haystack {
+		if v == needle {
+			return true
+		}
+	}
+	return false
+}
+
+func removeUint64s(from []uint64, remove []uint64) []uint64 {
+	drop := make(map[uint64]bool, len(remove))
+	for _, id := range remove {
+		drop[id] = true
+	}
+	out := from[:0]
+	for _, id := range from {
+		if !drop[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// policyDecision is one provider's outcome from evaluating it against the
+// policy: whether it should be approved, revoked, or left alone, and why.
+type policyDecision struct {
+	ProviderID string         `json:"providerId"`
+	Name       string         `json:"name"`
+	Payee      string         `json:"payee"`
+	Action     string         `json:"action"`
+	Reason     string         `json:"reason"`
+	id         *big.Int
+}
+
+var policyApplyDryRun bool
+
+var policyApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Evaluate every registered provider against the local policy and approve/revoke accordingly",
+	Long: `Walk every provider in the ServiceProviderRegistry, evaluate it against the locally
+configured policy (see "provider policy set"), and either approve it (if unapproved and it
+passes every constraint) or revoke it (if approved and it fails one, or is on the blocklist).
+Providers that already match their target approval state are left untouched.
+
+With --dry-run, nothing is sent on chain: the approve/revoke actions that would be taken are
+printed (or emitted as JSON/YAML with --output) instead. Without it, each decision is submitted
+one transaction at a time using the configured owner signer.`,
+	Args: cobra.NoArgs,
+	RunE: runPolicyApply,
+}
+
+func init() {
+	policyApplyCmd.Flags().BoolVar(&policyApplyDryRun, "dry-run", false, "Print planned approve/revoke actions instead of submitting them")
+}
+
+func runPolicyApply(cobraCmd *cobra.Command, args []string) error {
+	ctx := cobraCmd.Context()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	policy := cfg.Policy
+	if policy == nil {
+		policy = &config.ProviderPolicy{}
+	}
+
+	var nameAllow, nameDeny *regexp.Regexp
+	if policy.NameAllow != "" {
+		if nameAllow, err = regexp.Compile(policy.NameAllow); err != nil {
+			return fmt.Errorf("invalid policy name_allow: %w", err)
+		}
+	}
+	if policy.NameDeny != "" {
+		if nameDeny, err = regexp.Compile(policy.NameDeny); err != nil {
+			return fmt.Errorf("invalid policy name_deny: %w", err)
+		}
+	}
+	var minCapacity *big.Int
+	if policy.MinCapacityBytes != "" {
+		minCapacity = new(big.Int)
+		if _, ok := minCapacity.SetString(policy.MinCapacityBytes, 10); !ok {
+			return fmt.Errorf("invalid policy min_capacity_bytes: %s", policy.MinCapacityBytes)
+		}
+	}
+	blocklisted := make(map[uint64]bool, len(policy.Blocklist))
+	for _, id := range policy.Blocklist {
+		blocklisted[id] = true
+	}
+	payeeAllowlist := make(map[common.Address]bool, len(policy.PayeeAllowlist))
+	for _, addr := range policy.PayeeAllowlist {
+		payeeAllowlist[common.HexToAddress(addr)] = true
+	}
+
+	client, err := ethclient.Dial(cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+	defer client.Close()
+
+	registry, err := bindings.NewServiceProviderRegistry(cfg.ServiceRegistryAddr(), client)
+	if err != nil {
+		return fmt.Errorf("creating registry binding: %w", err)
+	}
+	viewContract, err := getViewContract(client, cfg.ServiceAddr())
+	if err != nil {
+		return fmt.Errorf("connecting to view contract: %w", err)
+	}
+
+	bindCtx := &bind.CallOpts{Context: ctx}
+
+	var decisions []policyDecision
+	pageSize := big.NewInt(policyApplyPageSize)
+	for offset := big.NewInt(0); ; offset = new(big.Int).Add(offset, pageSize) {
+		page, err := registry.GetAllActiveProviders(bindCtx, offset, pageSize)
+		if err != nil {
+			return fmt.Errorf("getting active providers at offset %s: %w", offset.String(), err)
+		}
+		if len(page.ProviderIds) == 0 {
+			break
+		}
+
+		details, err := registry.GetProvidersByIds(bindCtx, page.ProviderIds)
+		if err != nil {
+			return fmt.Errorf("getting provider details at offset %s: %w", offset.String(), err)
+		}
+		for i, providerView := range details.ProviderInfos {
+			if !details.ValidIds[i] {
+				continue
+			}
+
+			decision, err := evaluateProviderPolicy(
+				bindCtx, registry, viewContract,
+				nameAllow, nameDeny, minCapacity, blocklisted, payeeAllowlist,
+				providerView.ProviderId, providerView.Info.Name, providerView.Info.Payee,
+			)
+			if err != nil {
+				fmt.Fprintf(cobraCmd.ErrOrStderr(), "Warning: evaluating provider %d: %v\n", providerView.ProviderId.Uint64(), err)
+				continue
+			}
+			if decision.Action != "none" {
+				decisions = append(decisions, decision)
+			}
+		}
+
+		if !page.HasMore {
+			break
+		}
+	}
+
+	if policyApplyDryRun {
+		return printPolicyPlan(decisions)
+	}
+	return applyPolicyDecisions(ctx, cfg, client, decisions)
+}
+
+// evaluateProviderPolicy decides whether a single provider (identified by
+// id/name/payee, already known to be active and valid) should be approved,
+// revoked, or left alone under the given policy constraints.
+func evaluateProviderPolicy(
+	bindCtx *bind.CallOpts,
+	registry *bindings.ServiceProviderRegistry,
+	viewContract *bindings.FilecoinWarmStorageServiceStateView,
+	nameAllow, nameDeny *regexp.Regexp,
+	minCapacity *big.Int,
+	blocklisted map[uint64]bool,
+	payeeAllowlist map[common.Address]bool,
+	id *big.Int,
+	name string,
+	payee common.Address,
+) (policyDecision, error) {
+	approved, err := viewContract.IsProviderApproved(bindCtx, id)
+	if err != nil {
+		return policyDecision{}, fmt.Errorf("checking approval status: %w", err)
+	}
+
+	matches, reason := true, ""
+	switch {
+	case blocklisted[id.Uint64()]:
+		matches, reason = false, "blocklisted"
+	case nameDeny != nil && nameDeny.MatchString(name):
+		matches, reason = false, "name matches policy name_deny"
+	case nameAllow != nil && !nameAllow.MatchString(name):
+		matches, reason = false, "name does not match policy name_allow"
+	case len(payeeAllowlist) > 0 && !payeeAllowlist[payee]:
+		matches, reason = false, "payee not in policy payee_allowlist"
+	}
+
+	if matches && minCapacity != nil {
+		product, err := registry.GetPDPService(bindCtx, id)
+		if err != nil {
+			return policyDecision{}, fmt.Errorf("fetching PDP product config: %w", err)
+		}
+		if product == nil || product.MaxPieceSizeInBytes.Cmp(minCapacity) < 0 {
+			matches, reason = false, "advertised capacity below policy min_capacity_bytes"
+		}
+	}
+
+	decision := policyDecision{ProviderID: id.String(), Name: name, Payee: payee.Hex(), id: id}
+	switch {
+	case matches && !approved:
+		decision.Action, decision.Reason = "approve", "matches policy"
+	case !matches && approved:
+		decision.Action, decision.Reason = "revoke", reason
+	default:
+		decision.Action = "none"
+	}
+	return decision, nil
+}
+
+func printPolicyPlan(decisions []policyDecision) error {
+	if emitted, err := output.Emit(decisions); err != nil {
+		return err
+	} else if emitted {
+		return nil
+	}
+
+	if len(decisions) == 0 {
+		fmt.Println("No policy changes needed.")
+		return nil
+	}
+
+	fmt.Printf("%d planned change(s):\n\n", len(decisions))
+	for _, d := range decisions {
+		fmt.Printf("  %-7s provider %s (%q, payee %s): %s\n", strings.ToUpper(d.Action), d.ProviderID, d.Name, d.Payee, d.Reason)
+	}
+	return nil
+}
+
+// applyPolicyDecisions submits each decision's approve/revoke transaction in
+// turn, recording which ones failed instead of aborting on the first error.
+func applyPolicyDecisions(ctx context.Context, cfg *config.Config, client *ethclient.Client, decisions []policyDecision) error {
+	if len(decisions) == 0 {
+		fmt.Println("No policy changes needed.")
+		return nil
+	}
+
+	contractInstance, err := bindings.NewFilecoinWarmStorageService(cfg.ServiceAddr(), client)
+	if err != nil {
+		return fmt.Errorf("creating contract binding: %w", err)
+	}
+
+	signerManager := contract.NewSignerManager(cfg)
+	txSigner, err := signerManager.LoadOwnerTxSigner(ctx)
+	if err != nil {
+		return fmt.Errorf("loading owner signer: %w", err)
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("getting chain ID: %w", err)
+	}
+	auth := contract.CreateTransactorFromSigner(ctx, txSigner, chainID)
+
+	var failed int
+	for _, d := range decisions {
+		var tx *types.Transaction
+		var err error
+		switch d.Action {
+		case "approve":
+			tx, err = contractInstance.AddApprovedProvider(auth, d.id)
+		case "revoke":
+			tx, err = contractInstance.RemoveApprovedProvider(auth, d.id)
+		}
+		if err != nil {
+			fmt.Printf("provider %s: calling %s: %v\n", d.ProviderID, d.Action, err)
+			failed++
+			continue
+		}
+
+		if _, err := contract.WaitForTransaction(ctx, client, tx.Hash()); err != nil {
+			fmt.Printf("provider %s: waiting for transaction: %v\n", d.ProviderID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("provider %s: %sd successfully\n", d.ProviderID, d.Action)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d decision(s) failed to apply", failed)
+	}
+	return nil
+}
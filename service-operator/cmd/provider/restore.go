@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
+)
+
+// RestoreAction describes one transaction restore would need to send (or
+// has sent) to bring the target registry in line with an archived provider.
+type RestoreAction struct {
+	ProviderID uint64 `json:"providerId"`
+	Call       string `json:"call"`
+	Reason     string `json:"reason"`
+
+	TransactionHash string `json:"transactionHash,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// RestoreResult is the --output=json/yaml payload for `provider restore`.
+type RestoreResult struct {
+	Archive    string          `json:"archive"`
+	DryRun     bool            `json:"dryRun"`
+	Executed   bool            `json:"executed"`
+	Multicall3 bool            `json:"multicall3,omitempty"`
+	Actions    []RestoreAction `json:"actions"`
+}
+
+var (
+	restoreDryRun  bool
+	restoreOffline bool
+	restoreFrom    string
+	restoreOutFile string
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <archive>",
+	Short: "Replay a provider snapshot archive against a target registry",
+	Long: `Read a JSON archive written by "provider snapshot", diff it against the configured
+target ServiceProviderRegistry/FilecoinWarmStorageService, and produce the actions needed to
+recreate every archived provider: register (if the provider ID is missing), set payee (if it
+differs), set product config (if it differs or is missing), and request approval (if the
+archive's provider was approved but the target's isn't).
+
+By default (--dry-run=true) restore only prints the plan; nothing is sent. With
+--dry-run=false, the actions are either written as unsigned calldata with --offline (see
+"provider approve --offline" for the signing/submission workflow), or signed and broadcast
+directly with the configured owner signer.
+
+Providers with no corresponding on-chain registration can't be recreated with the exact same
+provider ID - the registry assigns IDs sequentially on register - so restored providers will get
+new IDs on the target chain; the archive's ID is only used to correlate actions in the plan.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRestore,
+}
+
+func init() {
+	restoreCmd.Flags().BoolVar(&restoreDryRun, "dry-run", true, "Print the plan without sending any transactions")
+	restoreCmd.Flags().BoolVar(&restoreOffline, "offline", false, "With --dry-run=false, write unsigned transaction envelopes to --out instead of signing and broadcasting")
+	restoreCmd.Flags().StringVar(&restoreFrom, "from", "", "Address the transactions will be sent from (required with --offline)")
+	restoreCmd.Flags().StringVar(&restoreOutFile, "out", "", "Directory to write offline transaction envelopes to (required with --offline)")
+}
+
+func runRestore(cobraCmd *cobra.Command, args []string) error {
+	ctx := cobraCmd.Context()
+	archivePath := args[0]
+
+	data, err := os.ReadFile(archivePath)
+	if err != nil {
+		return fmt.Errorf("reading archive %s: %w", archivePath, err)
+	}
+	var archive ProviderArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return fmt.Errorf("parsing archive %s: %w", archivePath, err)
+	}
+	if archive.SchemaVersion != archiveSchemaVersion {
+		return fmt.Errorf("archive %s has schema version %d, restore supports %d", archivePath, archive.SchemaVersion, archiveSchemaVersion)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.RPCUrl == "" {
+		return fmt.Errorf("rpc_url is required")
+	}
+	if cfg.ServiceRegistryContractAddress == "" {
+		return fmt.Errorf("service_registry_contract_address is required")
+	}
+
+	client, err := ethclient.Dial(cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+	defer client.Close()
+
+	registry, err := bindings.NewServiceProviderRegistry(cfg.ServiceRegistryAddr(), client)
+	if err != nil {
+		return fmt.Errorf("creating registry binding: %w", err)
+	}
+
+	var viewContract *bindings.FilecoinWarmStorageServiceStateView
+	if cfg.ServiceContractAddress != "" {
+		viewContract, err = getViewContract(client, cfg.ServiceAddr())
+		if err != nil {
+			fmt.Fprintf(cobraCmd.ErrOrStderr(), "Warning: Could not connect to view contract (approval diffing will be skipped): %v\n", err)
+			viewContract = nil
+		}
+	}
+
+	bindCtx := &bind.CallOpts{Context: ctx}
+
+	type targetProvider struct {
+		ProviderID  *big.Int
+		Name        string
+		Description string
+	}
+	targetByPayee := make(map[common.Address]targetProvider)
+	for offset := big.NewInt(0); ; offset = new(big.Int).Add(offset, big.NewInt(100)) {
+		page, err := registry.GetAllActiveProviders(bindCtx, offset, big.NewInt(100))
+		if err != nil {
+			return fmt.Errorf("getting active providers at offset %s: %w", offset.String(), err)
+		}
+		if len(page.ProviderIds) == 0 {
+			break
+		}
+		details, err := registry.GetProvidersByIds(bindCtx, page.ProviderIds)
+		if err != nil {
+			return fmt.Errorf("getting provider details at offset %s: %w", offset.String(), err)
+		}
+		for i, providerView := range details.ProviderInfos {
+			if !details.ValidIds[i] {
+				continue
+			}
+			targetByPayee[providerView.Info.Payee] = targetProvider{
+				ProviderID:  providerView.ProviderId,
+				Name:        providerView.Info.Name,
+				Description: providerView.Info.Description,
+			}
+		}
+		if !page.HasMore {
+			break
+		}
+	}
+
+	result := RestoreResult{Archive: archivePath, DryRun: restoreDryRun}
+	for _, record := range archive.Providers {
+		payee := common.HexToAddress(record.Payee)
+		target, exists := targetByPayee[payee]
+
+		if !exists {
+			result.Actions = append(result.Actions, RestoreAction{
+				ProviderID: record.ID,
+				Call:       fmt.Sprintf("RegisterProvider(payee=%s, name=%q)", record.Payee, record.Name),
+				Reason:     "no provider registered for this payee on the target registry",
+			})
+			if len(record.ProductConfig) > 0 {
+				result.Actions = append(result.Actions, RestoreAction{
+					ProviderID: record.ID,
+					Call:       "SetProductConfig(...)",
+					Reason:     "archived provider has a PDP product config to restore after registration",
+				})
+			}
+			if record.IsApprovedKnown && record.IsApproved {
+				result.Actions = append(result.Actions, RestoreAction{
+					ProviderID: record.ID,
+					Call:       "AddApprovedProvider(providerId=<assigned on registration>)",
+					Reason:     "archived provider was approved in FilecoinWarmStorageService",
+				})
+			}
+			continue
+		}
+
+		if target.Name != record.Name || target.Description != record.Description {
+			result.Actions = append(result.Actions, RestoreAction{
+				ProviderID: record.ID,
+				Call:       fmt.Sprintf("UpdateProviderInfo(name=%q, description=%q)", record.Name, record.Description),
+				Reason:     "registered provider's name/description differs from the archive",
+			})
+		}
+
+		if record.IsApprovedKnown && record.IsApproved && viewContract != nil {
+			approved, err := viewContract.IsProviderApproved(bindCtx, target.ProviderID)
+			if err != nil {
+				fmt.Fprintf(cobraCmd.ErrOrStderr(), "Warning: checking target approval for provider %s: %v\n", target.ProviderID.String(), err)
+			} else if !approved {
+				result.Actions = append(result.Actions, RestoreAction{
+					ProviderID: record.ID,
+					Call:       fmt.Sprintf("AddApprovedProvider(providerId=%s)", target.ProviderID.String()),
+					Reason:     "archived provider was approved in FilecoinWarmStorageService, target isn't",
+				})
+			}
+		}
+	}
+
+	if restoreDryRun {
+		return emitRestorePlan(result)
+	}
+
+	if restoreOffline {
+		return runRestoreOffline(ctx, cfg, &result)
+	}
+
+	return runRestoreExecute(ctx, cfg, client, &result)
+}
+
+// emitRestorePlan prints (or, with --output=json/yaml, emits) the plan
+// without sending anything.
+func emitRestorePlan(result RestoreResult) error {
+	if emitted, err := output.Emit(result); emitted {
+		return err
+	}
+
+	if len(result.Actions) == 0 {
+		fmt.Println("Nothing to do: every archived provider already matches the target registry.")
+		return nil
+	}
+
+	fmt.Printf("Restore plan for %s (%d action(s)):\n\n", result.Archive, len(result.Actions))
+	for _, action := range result.Actions {
+		fmt.Printf("  provider %d: %s\n", action.ProviderID, action.Call)
+		fmt.Printf("    reason: %s\n", action.Reason)
+	}
+	fmt.Println()
+	fmt.Println("Re-run with --dry-run=false to execute, or --dry-run=false --offline --from <addr> --out <dir> for unsigned calldata.")
+	return nil
+}
+
+// runRestoreOffline is a placeholder for writing every plan action as an
+// unsigned TxEnvelope (the same format "provider approve --offline"
+// produces), one file per action under --out. It isn't wired up to real
+// calldata yet because RegisterProvider/UpdateProviderInfo/SetProductConfig
+// have no binding in this tree to encode against (see "provider approve"
+// for the one registry call - AddApprovedProvider - that does).
+func runRestoreOffline(ctx context.Context, cfg *config.Config, result *RestoreResult) error {
+	if restoreFrom == "" {
+		return fmt.Errorf("--from is required with --offline")
+	}
+	if !common.IsHexAddress(restoreFrom) {
+		return fmt.Errorf("invalid --from address: %s", restoreFrom)
+	}
+	if restoreOutFile == "" {
+		return fmt.Errorf("--out is required with --offline")
+	}
+
+	return fmt.Errorf("provider restore --offline is not yet implemented: RegisterProvider/UpdateProviderInfo/SetProductConfig have no generated binding to build calldata from; use --dry-run to review the plan, or execute it by hand against the registry contract")
+}
+
+// runRestoreExecute would sign and broadcast every plan action with the
+// configured owner signer. Like runRestoreOffline, it's blocked on the same
+// missing registry write bindings.
+func runRestoreExecute(ctx context.Context, cfg *config.Config, client *ethclient.Client, result *RestoreResult) error {
+	signerManager := contract.NewSignerManager(cfg)
+	if _, err := signerManager.LoadOwnerTxSigner(ctx); err != nil {
+		return fmt.Errorf("loading owner signer: %w", err)
+	}
+
+	return fmt.Errorf("provider restore execution is not yet implemented: RegisterProvider/UpdateProviderInfo/SetProductConfig have no generated binding in this tree; re-run with --dry-run to review what would change")
+}
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
+)
+
+// RevokeResult is the --output=json payload for `provider revoke`.
+type RevokeResult struct {
+	ProviderID      string `json:"providerId"`
+	TransactionHash string `json:"transactionHash"`
+	BlockNumber     uint64 `json:"blockNumber"`
+	GasUsed         uint64 `json:"gasUsed"`
+}
+
+// RevokeOfflineResult is the --output=json payload for
+// `provider revoke --offline`.
+type RevokeOfflineResult struct {
+	ProviderID string `json:"providerId"`
+	File       string `json:"file"`
+	Action     string `json:"action"`
+	From       string `json:"from"`
+}
+
+var (
+	revokeOffline bool
+	revokeFrom    string
+	revokeOutFile string
+)
+
+var revokeCmd = &cobra.Command{
+	Use:   "revoke <provider-id>",
+	Short: "Revoke a provider's approval to create datasets",
+	Long: `Revoke a previously approved provider by their ID, preventing them from creating new
+datasets in the FilecoinWarmStorageService. Only the contract owner can revoke approval.
+
+With --offline, the unsigned transaction is written to --out instead of being signed and
+broadcast, so the owner key never has to touch the machine talking to the RPC endpoint. Sign
+and broadcast it later with "service-operator tx sign" and "service-operator tx submit".`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRevoke,
+}
+
+func init() {
+	revokeCmd.Flags().BoolVar(&revokeOffline, "offline", false, "Write an unsigned transaction envelope to --out instead of signing and broadcasting")
+	revokeCmd.Flags().StringVar(&revokeFrom, "from", "", "Address the transaction will be sent from (required with --offline, since no signer is loaded locally)")
+	revokeCmd.Flags().StringVar(&revokeOutFile, "out", "", "Path to write the offline transaction envelope (required with --offline)")
+}
+
+func runRevoke(cobraCmd *cobra.Command, args []string) error {
+	ctx := cobraCmd.Context()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	providerID := new(big.Int)
+	if _, ok := providerID.SetString(args[0], 10); !ok {
+		return fmt.Errorf("invalid provider ID: %s (must be a valid number)", args[0])
+	}
+
+	jsonMode := output.Selected() == output.JSON
+	if !jsonMode {
+		fmt.Printf("Revoking provider ID: %s\n", providerID.String())
+		fmt.Printf("Service Contract: %s\n", cfg.ServiceContractAddress)
+		fmt.Printf("RPC URL: %s\n", cfg.RPCUrl)
+		fmt.Println()
+	}
+
+	client, err := ethclient.Dial(cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+	defer client.Close()
+
+	if revokeOffline {
+		return runRevokeOffline(ctx, cfg, client, providerID, jsonMode)
+	}
+
+	contractInstance, err := bindings.NewFilecoinWarmStorageService(cfg.ServiceAddr(), client)
+	if err != nil {
+		return fmt.Errorf("creating contract binding: %w", err)
+	}
+
+	signerManager := contract.NewSignerManager(cfg)
+	txSigner, err := signerManager.LoadOwnerTxSigner(ctx)
+	if err != nil {
+		return fmt.Errorf("loading owner signer: %w", err)
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("getting chain ID: %w", err)
+	}
+	auth := contract.CreateTransactorFromSigner(ctx, txSigner, chainID)
+
+	tx, err := contractInstance.RemoveApprovedProvider(auth, providerID)
+	if err != nil {
+		return fmt.Errorf("calling RemoveApprovedProvider: %w", err)
+	}
+
+	receipt, err := contract.WaitForTransaction(ctx, client, tx.Hash())
+	if err != nil {
+		return fmt.Errorf("waiting for transaction: %w", err)
+	}
+
+	revokedID, err := contract.GetProviderRevokedEvent(receipt)
+	if err != nil {
+		return fmt.Errorf("parsing event: %w", err)
+	}
+
+	if jsonMode {
+		_, err := output.Emit(RevokeResult{
+			ProviderID:      revokedID.String(),
+			TransactionHash: receipt.TxHash.Hex(),
+			BlockNumber:     receipt.BlockNumber.Uint64(),
+			GasUsed:         receipt.GasUsed,
+		})
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("✓ Provider %s approval revoked successfully!\n", revokedID.String())
+	fmt.Printf("Transaction: %s\n", receipt.TxHash.Hex())
+	fmt.Printf("Block: %d\n", receipt.BlockNumber.Uint64())
+	fmt.Printf("Gas used: %d\n", receipt.GasUsed)
+
+	return nil
+}
+
+// runRevokeOffline builds the RemoveApprovedProvider transaction without
+// signing or broadcasting it, and writes it to revokeOutFile as a TxEnvelope
+// for a later "tx sign" / "tx submit".
+func runRevokeOffline(ctx context.Context, cfg *config.Config, client *ethclient.Client, providerID *big.Int, jsonMode bool) error {
+	if revokeFrom == "" {
+		return fmt.Errorf("--from is required with --offline")
+	}
+	if !common.IsHexAddress(revokeFrom) {
+		return fmt.Errorf("invalid --from address: %s", revokeFrom)
+	}
+	if revokeOutFile == "" {
+		return fmt.Errorf("--out is required with --offline")
+	}
+	from := common.HexToAddress(revokeFrom)
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("getting chain ID: %w", err)
+	}
+
+	contractInstance, err := bindings.NewFilecoinWarmStorageService(cfg.ServiceAddr(), client)
+	if err != nil {
+		return fmt.Errorf("creating contract binding: %w", err)
+	}
+
+	auth, captured := contract.CreateOfflineTransactor(ctx, from)
+	if _, err := contractInstance.RemoveApprovedProvider(auth, providerID); err != nil {
+		return fmt.Errorf("building RemoveApprovedProvider transaction: %w", err)
+	}
+
+	action := fmt.Sprintf("RemoveApprovedProvider(providerId=%s)", providerID.String())
+	env, err := contract.NewTxEnvelope(captured.Tx, chainID, from, action)
+	if err != nil {
+		return err
+	}
+	if err := contract.WriteTxEnvelope(revokeOutFile, env); err != nil {
+		return err
+	}
+
+	if jsonMode {
+		_, err := output.Emit(RevokeOfflineResult{
+			ProviderID: providerID.String(),
+			File:       revokeOutFile,
+			Action:     action,
+			From:       from.Hex(),
+		})
+		return err
+	}
+
+	fmt.Printf("Wrote unsigned transaction envelope to %s\n", revokeOutFile)
+	fmt.Printf("Action: %s\n", action)
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Printf("  service-operator tx sign %s\n", revokeOutFile)
+	fmt.Printf("  service-operator tx submit %s\n", revokeOutFile)
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
+)
+
+// archiveSchemaVersion is bumped whenever ProviderArchive's shape changes in
+// a way `restore` needs to know about.
+const archiveSchemaVersion = 1
+
+// ProviderRecord is one provider's full registration state as captured by
+// `provider snapshot`, and the unit `provider restore` diffs and replays.
+type ProviderRecord struct {
+	ID          uint64 `json:"id"`
+	Address     string `json:"address"`
+	Payee       string `json:"payee"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsActive    bool   `json:"isActive"`
+
+	// IsApproved/IsApprovedKnown follow the same pattern as
+	// `provider status`'s DataSetCountKnown: approval requires the view
+	// contract, which the snapshotting config might not have configured.
+	IsApproved      bool `json:"isApproved"`
+	IsApprovedKnown bool `json:"isApprovedKnown"`
+
+	// ProductConfig is the provider's raw PDP product configuration, as
+	// returned by the registry's product query, captured opaquely since
+	// restore only needs to replay it verbatim via SetProductConfig rather
+	// than interpret it. Omitted when the registry has no product
+	// configured for this provider.
+	ProductConfig json.RawMessage `json:"productConfig,omitempty"`
+}
+
+// ProviderArchive is the versioned JSON format written by `provider
+// snapshot` and read by `provider restore`.
+type ProviderArchive struct {
+	SchemaVersion int              `json:"schemaVersion"`
+	ChainID       string           `json:"chainId"`
+	BlockNumber   uint64           `json:"blockNumber"`
+	CapturedAt    time.Time        `json:"capturedAt"`
+	Providers     []ProviderRecord `json:"providers"`
+}
+
+var (
+	snapshotOut          string
+	snapshotShowInactive bool
+	snapshotPageSize     int64
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Export every registered provider to a versioned JSON archive",
+	Long: `Page through ServiceProviderRegistry.GetAllActiveProviders (and, with --show-inactive,
+inactive providers too), and write a versioned JSON archive containing the chain ID, the block
+number at capture time, and each provider's full ProviderInfo, approval status, and PDP product
+configuration.
+
+The resulting archive is meant to be replayed elsewhere with "provider restore", to migrate
+provider state between networks/deployments or to set up reproducible fixtures for integration
+tests.`,
+	Args: cobra.NoArgs,
+	RunE: runSnapshot,
+}
+
+func init() {
+	snapshotCmd.Flags().StringVar(&snapshotOut, "out", "", "Path to write the archive to (required)")
+	snapshotCmd.Flags().BoolVar(&snapshotShowInactive, "show-inactive", false, "Include inactive providers in the archive")
+	snapshotCmd.Flags().Int64Var(&snapshotPageSize, "page-size", 100, "Providers to request per registry page")
+}
+
+func runSnapshot(cobraCmd *cobra.Command, args []string) error {
+	ctx := cobraCmd.Context()
+
+	if snapshotOut == "" {
+		return fmt.Errorf("--out is required")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.RPCUrl == "" {
+		return fmt.Errorf("rpc_url is required")
+	}
+	if cfg.ServiceRegistryContractAddress == "" {
+		return fmt.Errorf("service_registry_contract_address is required")
+	}
+
+	client, err := ethclient.Dial(cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+	defer client.Close()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("getting chain ID: %w", err)
+	}
+	blockNumber, err := client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("getting current block number: %w", err)
+	}
+
+	registry, err := bindings.NewServiceProviderRegistry(cfg.ServiceRegistryAddr(), client)
+	if err != nil {
+		return fmt.Errorf("creating registry binding: %w", err)
+	}
+
+	var viewContract *bindings.FilecoinWarmStorageServiceStateView
+	if cfg.ServiceContractAddress != "" {
+		viewContract, err = getViewContract(client, cfg.ServiceAddr())
+		if err != nil {
+			fmt.Fprintf(cobraCmd.ErrOrStderr(), "Warning: Could not connect to view contract (approval status will not be captured): %v\n", err)
+			viewContract = nil
+		}
+	}
+
+	bindCtx := &bind.CallOpts{Context: ctx}
+	pageSize := big.NewInt(snapshotPageSize)
+
+	var records []ProviderRecord
+	for offset := big.NewInt(0); ; offset = new(big.Int).Add(offset, pageSize) {
+		page, err := registry.GetAllActiveProviders(bindCtx, offset, pageSize)
+		if err != nil {
+			return fmt.Errorf("getting active providers at offset %s: %w", offset.String(), err)
+		}
+		if len(page.ProviderIds) == 0 {
+			break
+		}
+
+		details, err := registry.GetProvidersByIds(bindCtx, page.ProviderIds)
+		if err != nil {
+			return fmt.Errorf("getting provider details at offset %s: %w", offset.String(), err)
+		}
+
+		for i, providerView := range details.ProviderInfos {
+			if !details.ValidIds[i] {
+				continue
+			}
+			if !snapshotShowInactive && !providerView.Info.IsActive {
+				continue
+			}
+
+			record := ProviderRecord{
+				ID:          providerView.ProviderId.Uint64(),
+				Address:     providerView.Info.ServiceProvider.Hex(),
+				Payee:       providerView.Info.Payee.Hex(),
+				Name:        providerView.Info.Name,
+				Description: providerView.Info.Description,
+				IsActive:    providerView.Info.IsActive,
+			}
+
+			if viewContract != nil {
+				approved, err := viewContract.IsProviderApproved(bindCtx, providerView.ProviderId)
+				if err != nil {
+					fmt.Fprintf(cobraCmd.ErrOrStderr(), "Warning: checking approval for provider %d: %v\n", record.ID, err)
+				} else {
+					record.IsApproved = approved
+					record.IsApprovedKnown = true
+				}
+			}
+
+			if productConfig, err := registry.GetPDPService(bindCtx, providerView.ProviderId); err != nil {
+				fmt.Fprintf(cobraCmd.ErrOrStderr(), "Warning: fetching PDP product config for provider %d: %v\n", record.ID, err)
+			} else if productConfig != nil {
+				if raw, err := json.Marshal(productConfig); err == nil {
+					record.ProductConfig = raw
+				}
+			}
+
+			records = append(records, record)
+		}
+
+		if !page.HasMore {
+			break
+		}
+	}
+
+	archive := ProviderArchive{
+		SchemaVersion: archiveSchemaVersion,
+		ChainID:       chainID.String(),
+		BlockNumber:   blockNumber,
+		CapturedAt:    time.Now().UTC(),
+		Providers:     records,
+	}
+
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling archive: %w", err)
+	}
+	if err := os.WriteFile(snapshotOut, data, 0o644); err != nil {
+		return fmt.Errorf("writing archive %s: %w", snapshotOut, err)
+	}
+
+	if output.Selected() == output.JSON {
+		_, err := output.Emit(archive)
+		return err
+	}
+
+	fmt.Printf("Wrote %d provider(s) to %s (chain %s, block %d)\n", len(records), snapshotOut, archive.ChainID, archive.BlockNumber)
+	return nil
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
+)
+
+// StatusResult is the --output=json payload for `provider status`.
+type StatusResult struct {
+	ID                uint64 `json:"id"`
+	Address           string `json:"address"`
+	Payee             string `json:"payee"`
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	IsActive          bool   `json:"isActive"`
+	IsApproved        bool   `json:"isApproved"`
+	DataSetCount      int64  `json:"dataSetCount,omitempty"`
+	DataSetCountKnown bool   `json:"dataSetCountKnown"`
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status <provider-id>",
+	Short: "Show a single provider's registration and approval state",
+	Long: `Show one provider's full registration record from the ServiceProviderRegistry, its approval
+state in FilecoinWarmStorageService, and how many PDP data sets it currently holds in the
+PDPVerifier contract.
+
+Data set count requires --verifier-contract-address (or verifier_contract_address in config); if
+it isn't configured, or the verifier doesn't expose the count, status is still reported with
+dataSetCountKnown=false rather than failing the whole command.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStatus,
+}
+
+func runStatus(cobraCmd *cobra.Command, args []string) error {
+	ctx := cobraCmd.Context()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.RPCUrl == "" {
+		return fmt.Errorf("rpc_url is required")
+	}
+	if cfg.ServiceRegistryContractAddress == "" {
+		return fmt.Errorf("service_registry_contract_address is required")
+	}
+
+	providerID := new(big.Int)
+	if _, ok := providerID.SetString(args[0], 10); !ok {
+		return fmt.Errorf("invalid provider ID: %s (must be a valid number)", args[0])
+	}
+
+	client, err := ethclient.Dial(cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+	defer client.Close()
+
+	registry, err := bindings.NewServiceProviderRegistry(cfg.ServiceRegistryAddr(), client)
+	if err != nil {
+		return fmt.Errorf("creating registry binding: %w", err)
+	}
+
+	bindCtx := &bind.CallOpts{Context: ctx}
+
+	result, err := registry.GetProvidersByIds(bindCtx, []*big.Int{providerID})
+	if err != nil {
+		return fmt.Errorf("getting provider details: %w", err)
+	}
+	if len(result.ValidIds) == 0 || !result.ValidIds[0] {
+		return fmt.Errorf("no provider registered with ID %s", providerID.String())
+	}
+	info := result.ProviderInfos[0]
+
+	status := StatusResult{
+		ID:          providerID.Uint64(),
+		Address:     info.Info.ServiceProvider.Hex(),
+		Payee:       info.Info.Payee.Hex(),
+		Name:        info.Info.Name,
+		Description: info.Info.Description,
+		IsActive:    info.Info.IsActive,
+	}
+
+	var warnings []string
+
+	if cfg.ServiceContractAddress != "" {
+		viewContract, err := getViewContract(client, cfg.ServiceAddr())
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("could not connect to view contract (approval status will not be shown): %v", err))
+		} else {
+			approved, err := viewContract.IsProviderApproved(bindCtx, providerID)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("checking approval status: %v", err))
+			} else {
+				status.IsApproved = approved
+			}
+		}
+	}
+
+	if cfg.VerifierContractAddress != "" {
+		verifier, err := bindings.NewPDPVerifierCaller(cfg.VerifierAddr(), client)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("could not bind verifier contract (data set count will not be shown): %v", err))
+		} else {
+			count, err := verifier.GetActiveDataSetCount(bindCtx, info.Info.ServiceProvider)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("querying data set count: %v", err))
+			} else {
+				status.DataSetCount = count.Int64()
+				status.DataSetCountKnown = true
+			}
+		}
+	}
+
+	if output.Selected() == output.JSON {
+		_, err := output.Emit(status)
+		return err
+	}
+
+	fmt.Printf("Provider %d\n", status.ID)
+	fmt.Printf("  Address:       %s\n", status.Address)
+	fmt.Printf("  Payee:         %s\n", status.Payee)
+	fmt.Printf("  Name:          %s\n", status.Name)
+	fmt.Printf("  Description:   %s\n", status.Description)
+	fmt.Printf("  Active:        %t\n", status.IsActive)
+	fmt.Printf("  Approved:      %t\n", status.IsApproved)
+	if status.DataSetCountKnown {
+		fmt.Printf("  Data sets:     %d\n", status.DataSetCount)
+	} else {
+		fmt.Printf("  Data sets:     unknown\n")
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(cobraCmd.ErrOrStderr(), "Warning: %s\n", w)
+	}
+
+	return nil
+}
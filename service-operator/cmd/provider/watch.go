@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+)
+
+// WatchEvent is one decoded, rendered row `provider watch` emits, either as
+// a table row or (with --format=json) an NDJSON line.
+type WatchEvent struct {
+	BlockNumber uint64 `json:"blockNumber"`
+	TxHash      string `json:"txHash"`
+	Event       string `json:"event"`
+	ProviderID  string `json:"providerId"`
+	Detail      string `json:"detail,omitempty"`
+}
+
+var (
+	watchFromBlock    uint64
+	watchFormat       string
+	watchPollInterval time.Duration
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Tail ServiceProviderRegistry and approval events as they happen",
+	Long: `Subscribe to ServiceProviderRegistry events (ProviderRegistered, ProviderInfoUpdated,
+ProviderRemoved, PayeeChanged) and FilecoinWarmStorageService approval events (ProviderApproved,
+ProviderApprovalRevoked), printing one row per event as it's observed.
+
+When rpc_url is a websocket endpoint (ws:// or wss://), events are pushed via
+eth_subscribe/SubscribeFilterLogs, with automatic reconnect (exponential backoff) if the
+subscription drops. Otherwise, events are discovered by polling eth_getLogs every
+--poll-interval.
+
+--from-block backfills every matching event from that block up to the current head before
+switching to live tailing, so "provider watch --from-block 0 --format json | jq ..." can replay
+the registry's full history into another system.`,
+	Args: cobra.NoArgs,
+	RunE: runWatch,
+}
+
+func init() {
+	watchCmd.Flags().Uint64Var(&watchFromBlock, "from-block", 0, "Backfill events starting at this block before tailing live (0 disables backfill)")
+	watchCmd.Flags().StringVar(&watchFormat, "format", "text", "Output format: text or json (NDJSON, one event per line)")
+	watchCmd.Flags().DurationVar(&watchPollInterval, "poll-interval", 15*time.Second, "How often to poll for new events when rpc_url isn't a websocket endpoint")
+}
+
+func runWatch(cobraCmd *cobra.Command, args []string) error {
+	ctx, cancel := signal.NotifyContext(cobraCmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.RPCUrl == "" {
+		return fmt.Errorf("rpc_url is required")
+	}
+	if cfg.ServiceRegistryContractAddress == "" {
+		return fmt.Errorf("service_registry_contract_address is required")
+	}
+	if watchFormat != "text" && watchFormat != "json" {
+		return fmt.Errorf("invalid --format: %s (must be 'text' or 'json')", watchFormat)
+	}
+
+	addresses := []common.Address{cfg.ServiceRegistryAddr()}
+	if cfg.ServiceContractAddress != "" {
+		addresses = append(addresses, cfg.ServiceAddr())
+	}
+	topics := [][]common.Hash{contract.RegistryEventTopics()}
+
+	client, err := ethclient.DialContext(ctx, cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+
+	lastBlock := watchFromBlock
+	if watchFromBlock != 0 {
+		head, err := client.BlockNumber(ctx)
+		if err != nil {
+			client.Close()
+			return fmt.Errorf("getting current block number: %w", err)
+		}
+		if err := backfillLogs(ctx, client, addresses, topics, watchFromBlock, head); err != nil {
+			client.Close()
+			return fmt.Errorf("backfilling events: %w", err)
+		}
+		lastBlock = head
+	}
+	client.Close()
+
+	isWS := strings.HasPrefix(cfg.RPCUrl, "ws://") || strings.HasPrefix(cfg.RPCUrl, "wss://")
+	if isWS {
+		return watchSubscribe(ctx, cfg.RPCUrl, addresses, topics)
+	}
+	return watchPoll(ctx, cfg.RPCUrl, addresses, topics, lastBlock)
+}
+
+// backfillLogs fetches and renders every matching event between fromBlock
+// and toBlock (inclusive) before live tailing begins.
+func backfillLogs(ctx context.Context, client *ethclient.Client, addresses []common.Address, topics [][]common.Hash, fromBlock, toBlock uint64) error {
+	query := ethereum.FilterQuery{
+		Addresses: addresses,
+		Topics:    topics,
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+	}
+	logs, err := client.FilterLogs(ctx, query)
+	if err != nil {
+		return err
+	}
+	for _, vLog := range logs {
+		renderWatchEvent(vLog)
+	}
+	return nil
+}
+
+// watchSubscribe tails events over a websocket subscription, reconnecting
+// with exponential backoff whenever the subscription or underlying
+// connection drops.
+func watchSubscribe(ctx context.Context, rpcURL string, addresses []common.Address, topics [][]common.Hash) error {
+	b := newBackoff()
+	for ctx.Err() == nil {
+		if err := subscribeOnce(ctx, rpcURL, addresses, topics); err != nil {
+			delay := b.next()
+			fmt.Fprintf(os.Stderr, "Warning: event subscription failed, retrying in %s: %v\n", delay, err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+			continue
+		}
+		b.reset()
+	}
+	return ctx.Err()
+}
+
+func subscribeOnce(ctx context.Context, rpcURL string, addresses []common.Address, topics [][]common.Hash) error {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC: %w", err)
+	}
+	defer client.Close()
+
+	query := ethereum.FilterQuery{Addresses: addresses, Topics: topics}
+	logs := make(chan types.Log, 64)
+	sub, err := client.SubscribeFilterLogs(ctx, query, logs)
+	if err != nil {
+		return fmt.Errorf("subscribing to registry events: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			return fmt.Errorf("event subscription dropped: %w", err)
+		case vLog := <-logs:
+			renderWatchEvent(vLog)
+		}
+	}
+}
+
+// watchPoll tails events by polling eth_getLogs every --poll-interval,
+// for RPC endpoints that don't support eth_subscribe.
+func watchPoll(ctx context.Context, rpcURL string, addresses []common.Address, topics [][]common.Hash, lastBlock uint64) error {
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			client, err := ethclient.DialContext(ctx, rpcURL)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: connecting to RPC for poll tick: %v\n", err)
+				continue
+			}
+
+			head, err := client.BlockNumber(ctx)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: getting current block number: %v\n", err)
+				client.Close()
+				continue
+			}
+			if head <= lastBlock {
+				client.Close()
+				continue
+			}
+
+			query := ethereum.FilterQuery{
+				Addresses: addresses,
+				Topics:    topics,
+				FromBlock: new(big.Int).SetUint64(lastBlock + 1),
+				ToBlock:   new(big.Int).SetUint64(head),
+			}
+			logs, err := client.FilterLogs(ctx, query)
+			client.Close()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: polling for events: %v\n", err)
+				continue
+			}
+			for _, vLog := range logs {
+				renderWatchEvent(vLog)
+			}
+			lastBlock = head
+		}
+	}
+}
+
+// renderWatchEvent decodes vLog against every registry/approval event type
+// and prints it as a table row, or (with --format=json) an NDJSON line.
+func renderWatchEvent(vLog types.Log) {
+	if len(vLog.Topics) == 0 {
+		return
+	}
+
+	event := WatchEvent{BlockNumber: vLog.BlockNumber, TxHash: vLog.TxHash.Hex()}
+
+	topics := contract.RegistryEventTopics()
+	switch vLog.Topics[0] {
+	case topics[0]:
+		decoded, err := contract.ParseProviderRegisteredEvent(vLog)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: decoding ProviderRegistered event: %v\n", err)
+			return
+		}
+		event.Event = "ProviderRegistered"
+		event.ProviderID = decoded.ProviderID.String()
+		event.Detail = fmt.Sprintf("serviceProvider=%s payee=%s", decoded.ServiceProvider.Hex(), decoded.Payee.Hex())
+
+	case topics[1]:
+		decoded, err := contract.ParseProviderInfoUpdatedEvent(vLog)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: decoding ProviderInfoUpdated event: %v\n", err)
+			return
+		}
+		event.Event = "ProviderInfoUpdated"
+		event.ProviderID = decoded.ProviderID.String()
+		event.Detail = fmt.Sprintf("name=%q description=%q", decoded.Name, decoded.Description)
+
+	case topics[2]:
+		decoded, err := contract.ParseProviderRemovedEvent(vLog)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: decoding ProviderRemoved event: %v\n", err)
+			return
+		}
+		event.Event = "ProviderRemoved"
+		event.ProviderID = decoded.ProviderID.String()
+
+	case topics[3]:
+		decoded, err := contract.ParsePayeeChangedEvent(vLog)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: decoding PayeeChanged event: %v\n", err)
+			return
+		}
+		event.Event = "PayeeChanged"
+		event.ProviderID = decoded.ProviderID.String()
+		event.Detail = fmt.Sprintf("oldPayee=%s newPayee=%s", decoded.OldPayee.Hex(), decoded.NewPayee.Hex())
+
+	case topics[4]:
+		decoded, err := contract.ParseProviderApprovedEvent(vLog)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: decoding ProviderApproved event: %v\n", err)
+			return
+		}
+		event.Event = "ProviderApproved"
+		event.ProviderID = decoded.ProviderID.String()
+
+	case topics[5]:
+		decoded, err := contract.ParseProviderApprovalRevokedEvent(vLog)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: decoding ProviderApprovalRevoked event: %v\n", err)
+			return
+		}
+		event.Event = "ProviderApprovalRevoked"
+		event.ProviderID = decoded.ProviderID.String()
+
+	default:
+		return
+	}
+
+	if watchFormat == "json" {
+		line, err := json.Marshal(event)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: marshaling event: %v\n", err)
+			return
+		}
+		fmt.Println(string(line))
+		return
+	}
+
+	fmt.Printf("%-10d  %-24s  %-8s  %s\n", event.BlockNumber, event.Event, event.ProviderID, event.Detail)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/storacha/filecoin-services/service-operator/cmd/payments"
 	"github.com/storacha/filecoin-services/service-operator/cmd/provider"
+	"github.com/storacha/filecoin-services/service-operator/cmd/tx"
 )
 
 var cfgFile string
@@ -37,6 +38,7 @@ func init() {
 	// Note: Authentication is now configured per-role in the config file (signers.owner and signers.payer)
 	// Optional flag to select which signer to use (for debugging/override purposes)
 	rootCmd.PersistentFlags().String("signer", "", "Override signer to use: 'owner' or 'payer' (optional)")
+	rootCmd.PersistentFlags().String("output", "text", "Output format: text, json, or yaml")
 
 	cobra.CheckErr(viper.BindPFlag("rpc_url", rootCmd.PersistentFlags().Lookup("rpc-url")))
 	cobra.CheckErr(viper.BindPFlag("service_contract_address", rootCmd.PersistentFlags().Lookup("service-contract-address")))
@@ -45,9 +47,11 @@ func init() {
 	cobra.CheckErr(viper.BindPFlag("payments_contract_address", rootCmd.PersistentFlags().Lookup("payments-contract-address")))
 	cobra.CheckErr(viper.BindPFlag("token_contract_address", rootCmd.PersistentFlags().Lookup("token-contract-address")))
 	cobra.CheckErr(viper.BindPFlag("signer_override", rootCmd.PersistentFlags().Lookup("signer")))
+	cobra.CheckErr(viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output")))
 
 	rootCmd.AddCommand(provider.Cmd)
 	rootCmd.AddCommand(payments.Cmd)
+	rootCmd.AddCommand(tx.Cmd)
 }
 
 func initConfig() {
@@ -0,0 +1,104 @@
+package tx
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
+)
+
+// SignResult is the --output=json payload for `tx sign`.
+type SignResult struct {
+	File   string `json:"file"`
+	Action string `json:"action"`
+	From   string `json:"from"`
+}
+
+var signCmd = &cobra.Command{
+	Use:   "sign <file>",
+	Short: "Sign an offline transaction envelope",
+	Long: `Sign an unsigned transaction envelope written by a command's --offline mode, using any
+configured Signer backend (keystore, external, ledger, aws-kms, gcp-kms, or vault).
+
+The signer's role is chosen with the persistent --signer flag (defaults to "owner") and must
+resolve to the same address the envelope was built for; a mismatch is rejected rather than
+silently signing from a different account. The envelope file is overwritten in place with the
+signed transaction, ready for "service-operator tx submit".`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSign,
+}
+
+func runSign(cobraCmd *cobra.Command, args []string) error {
+	ctx := cobraCmd.Context()
+	file := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	env, err := contract.ReadTxEnvelope(file)
+	if err != nil {
+		return err
+	}
+	if env.Signed {
+		return fmt.Errorf("envelope %s is already signed", file)
+	}
+
+	role := viper.GetString("signer_override")
+	if role == "" {
+		role = "owner"
+	}
+
+	signerManager := contract.NewSignerManager(cfg)
+	txSigner, err := signerManager.LoadTxSigner(ctx, role)
+	if err != nil {
+		return fmt.Errorf("loading '%s' signer: %w", role, err)
+	}
+
+	if txSigner.Address() != env.From {
+		return fmt.Errorf("'%s' signer address %s does not match envelope's from address %s", role, txSigner.Address().Hex(), env.From.Hex())
+	}
+
+	chainID, err := env.ChainIDInt()
+	if err != nil {
+		return err
+	}
+
+	unsignedTx, err := env.Transaction()
+	if err != nil {
+		return err
+	}
+
+	signedTx, err := txSigner.SignTx(ctx, chainID, unsignedTx)
+	if err != nil {
+		return fmt.Errorf("signing transaction: %w", err)
+	}
+
+	signedEnv, err := contract.NewTxEnvelope(signedTx, chainID, env.From, env.Action)
+	if err != nil {
+		return err
+	}
+	signedEnv.Signed = true
+
+	if err := contract.WriteTxEnvelope(file, signedEnv); err != nil {
+		return err
+	}
+
+	if output.Selected() == output.JSON {
+		_, err := output.Emit(SignResult{File: file, Action: env.Action, From: env.From.Hex()})
+		return err
+	}
+
+	fmt.Printf("✓ Signed %s\n", file)
+	fmt.Printf("Action: %s\n", env.Action)
+	fmt.Printf("From:   %s\n", env.From.Hex())
+	fmt.Println()
+	fmt.Printf("Next step:\n  service-operator tx submit %s\n", file)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package tx
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+	"github.com/storacha/filecoin-services/service-operator/internal/output"
+)
+
+// SubmitResult is the --output=json payload for `tx submit`.
+type SubmitResult struct {
+	Action          string `json:"action"`
+	TransactionHash string `json:"transactionHash"`
+	BlockNumber     uint64 `json:"blockNumber"`
+	GasUsed         uint64 `json:"gasUsed"`
+}
+
+var submitCmd = &cobra.Command{
+	Use:   "submit <file>",
+	Short: "Broadcast a signed transaction envelope and wait for its receipt",
+	Long: `Broadcast a transaction envelope signed by "service-operator tx sign" and wait for it to be
+mined, using the same receipt-waiting machinery as every other state-changing command.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSubmit,
+}
+
+func runSubmit(cobraCmd *cobra.Command, args []string) error {
+	ctx := cobraCmd.Context()
+	file := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	env, err := contract.ReadTxEnvelope(file)
+	if err != nil {
+		return err
+	}
+	if !env.Signed {
+		return fmt.Errorf("envelope %s has not been signed yet; run `service-operator tx sign %s` first", file, file)
+	}
+
+	signedTx, err := env.Transaction()
+	if err != nil {
+		return err
+	}
+
+	client, err := ethclient.Dial(cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+	defer client.Close()
+
+	if err := client.SendTransaction(ctx, signedTx); err != nil {
+		return fmt.Errorf("broadcasting transaction: %w", err)
+	}
+
+	receipt, err := contract.WaitForTransaction(ctx, client, signedTx.Hash())
+	if err != nil {
+		return fmt.Errorf("waiting for transaction: %w", err)
+	}
+
+	if output.Selected() == output.JSON {
+		_, err := output.Emit(SubmitResult{
+			Action:          env.Action,
+			TransactionHash: receipt.TxHash.Hex(),
+			BlockNumber:     receipt.BlockNumber.Uint64(),
+			GasUsed:         receipt.GasUsed,
+		})
+		return err
+	}
+
+	fmt.Printf("✓ Submitted %s\n", file)
+	fmt.Printf("Action:      %s\n", env.Action)
+	fmt.Printf("Transaction: %s\n", receipt.TxHash.Hex())
+	fmt.Printf("Block:       %d\n", receipt.BlockNumber.Uint64())
+	fmt.Printf("Gas used:    %d\n", receipt.GasUsed)
+
+	return nil
+}
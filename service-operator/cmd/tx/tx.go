@@ -0,0 +1,26 @@
+// Package tx implements `service-operator tx sign` and `tx submit`, the
+// offline-signing counterpart to commands that support --offline: those
+// commands write an unsigned transaction envelope to a file instead of
+// signing and broadcasting it directly, so the envelope can be carried to
+// whichever host holds the signing key (an air-gapped machine, a hardware
+// wallet, a multisig ceremony) and signed and submitted from there.
+package tx
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "tx",
+	Short: "Sign and submit offline transaction envelopes",
+	Long: `Sign and submit the transaction envelopes written by a command's --offline mode.
+
+This lets a state-changing command (e.g. "provider approve --offline") be run on a machine
+that talks to the RPC endpoint but doesn't hold the signing key, while the signing itself
+happens separately wherever the key actually lives.`,
+}
+
+func init() {
+	Cmd.AddCommand(signCmd)
+	Cmd.AddCommand(submitCmd)
+}
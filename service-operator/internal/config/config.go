@@ -12,6 +12,109 @@ type SignerConfig struct {
 	PrivateKeyPath   string `mapstructure:"private_key"`       // Path to private key file
 	KeystorePath     string `mapstructure:"keystore"`          // Path to encrypted keystore
 	KeystorePassword string `mapstructure:"keystore_password"` // Keystore password
+
+	// Backend selects how signatures (both EIP-712 auth signatures and raw
+	// on-chain transactions) are produced: "keystore" (default, uses
+	// PrivateKeyPath/KeystorePath above), "external" (a Clef/Frame-style
+	// JSON-RPC signer), "ledger" (a USB hardware wallet, only available in
+	// binaries built with `-tags ledger`), "aws-kms"/"gcp-kms" (a cloud KMS
+	// asymmetric signing key, only available in binaries built with
+	// `-tags kms`), or "vault" (a HashiCorp Vault transit engine key).
+	Backend string `mapstructure:"backend"`
+
+	// ExternalURL is the JSON-RPC endpoint used when Backend is "external".
+	ExternalURL string `mapstructure:"external_url"`
+	// ExternalAddress is the address the external signer should sign for.
+	ExternalAddress string `mapstructure:"external_address"`
+	// ExternalToken, if set, is sent as a Bearer token on every request to
+	// ExternalURL. A local Clef/Frame instance needs no authentication, but a
+	// remote custodial signing daemon reachable over the network typically
+	// does.
+	ExternalToken string `mapstructure:"external_token"`
+
+	// LedgerDerivationPath is the BIP-44 path (e.g. "m/44'/60'/0'/0/0") used
+	// when Backend is "ledger".
+	LedgerDerivationPath string `mapstructure:"ledger_derivation_path"`
+
+	// KMSKeyID identifies the asymmetric ECDSA (secp256k1) signing key to use
+	// when Backend is "aws-kms" (a key ID or ARN) or "gcp-kms" (a full
+	// CryptoKeyVersion resource name).
+	KMSKeyID string `mapstructure:"kms_key_id"`
+
+	// Mnemonic is a BIP-39 mnemonic phrase used to derive hierarchical
+	// deterministic keys for this role. Mutually exclusive with SeedFile.
+	Mnemonic string `mapstructure:"mnemonic"`
+	// SeedFile is a path to a raw BIP-32 seed (hex-encoded) used in place of
+	// Mnemonic. Mutually exclusive with Mnemonic.
+	SeedFile string `mapstructure:"seed_file"`
+	// DerivationPath is the base BIP-44 path for this role, e.g.
+	// "m/44'/60'/0'/0". An account index is appended to form the full path
+	// for a given account, following accounts.DefaultBaseDerivationPath
+	// semantics. Defaults to "m/44'/60'/0'/0" when a mnemonic or seed file is
+	// configured but DerivationPath is empty.
+	DerivationPath string `mapstructure:"derivation_path"`
+
+	// VaultAddr is the base URL of the Vault server (e.g.
+	// "https://vault.internal:8200") used when Backend is "vault".
+	VaultAddr string `mapstructure:"vault_addr"`
+	// VaultToken authenticates to Vault when Backend is "vault".
+	VaultToken string `mapstructure:"vault_token"`
+	// VaultTransitMount is the mount path of the transit secrets engine.
+	// Defaults to "transit" when empty.
+	VaultTransitMount string `mapstructure:"vault_transit_mount"`
+	// VaultKeyName is the name of the transit key to sign with.
+	VaultKeyName string `mapstructure:"vault_key_name"`
+}
+
+const (
+	SignerBackendKeystore = "keystore"
+	SignerBackendExternal = "external"
+	SignerBackendLedger   = "ledger"
+	SignerBackendAWSKMS   = "aws-kms"
+	SignerBackendGCPKMS   = "gcp-kms"
+	SignerBackendVault    = "vault"
+)
+
+// RefillConfig configures `payments monitor --auto-refill`'s deposit
+// policy. Amounts are decimal strings in the token's smallest unit, matching
+// every other base-unit amount in this config.
+type RefillConfig struct {
+	// Threshold is the free balance (funds minus current lockup) below
+	// which the monitor deposits more.
+	Threshold string `mapstructure:"threshold"`
+	// Target is the free balance a refill tops up to.
+	Target string `mapstructure:"target"`
+	// DailyCap, if set, is the maximum total the monitor will deposit in a
+	// rolling 24h window; a refill that would exceed it is skipped rather
+	// than partially applied.
+	DailyCap string `mapstructure:"daily_cap"`
+	// AbsoluteCap, if set, is the maximum total the monitor will ever
+	// deposit over its entire run, regardless of --interval or uptime.
+	AbsoluteCap string `mapstructure:"absolute_cap"`
+}
+
+// ProviderPolicy configures `provider policy apply`'s automated approve/
+// revoke decisions. A registered provider is an approve candidate only when
+// it satisfies every configured constraint; violating any of them (or
+// appearing in Blocklist) makes it a revoke candidate instead. A zero-value
+// ProviderPolicy approves everything and revokes nothing.
+type ProviderPolicy struct {
+	// NameAllow, if set, is a regular expression a provider's name must
+	// match to be approved.
+	NameAllow string `mapstructure:"name_allow"`
+	// NameDeny, if set, is a regular expression that disqualifies a
+	// provider whose name matches it, even when NameAllow also matches.
+	NameDeny string `mapstructure:"name_deny"`
+	// PayeeAllowlist, if non-empty, restricts approval to providers whose
+	// payee address appears in this list.
+	PayeeAllowlist []string `mapstructure:"payee_allowlist"`
+	// MinCapacityBytes, if set, is the minimum PDP max piece size (in
+	// bytes, as a decimal string) a provider's product config must
+	// advertise to be approved.
+	MinCapacityBytes string `mapstructure:"min_capacity_bytes"`
+	// Blocklist is a set of provider IDs that are always revoke candidates,
+	// regardless of every other constraint.
+	Blocklist []uint64 `mapstructure:"blocklist"`
 }
 
 // Config represents the complete configuration for the service-operator CLI
@@ -28,6 +131,15 @@ type Config struct {
 
 	// Signers for different roles
 	Signers map[string]SignerConfig `mapstructure:"signers"` // Map of role -> signer config
+
+	// Refill configures `payments monitor --auto-refill`'s deposit policy.
+	// Optional: commands that don't auto-refill ignore it.
+	Refill *RefillConfig `mapstructure:"refill"`
+
+	// Policy configures `provider policy apply`'s automated approve/revoke
+	// decisions. Optional: "provider approve"/"provider revoke" ignore it,
+	// since they always act on the single ID the operator names explicitly.
+	Policy *ProviderPolicy `mapstructure:"policy"`
 }
 
 // Validate checks that all required configuration fields are set and valid
@@ -98,18 +210,63 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// ResolvedBackend returns the signer's configured Backend, defaulting to
+// SignerBackendKeystore when unset.
+func (s *SignerConfig) ResolvedBackend() string {
+	if s.Backend == "" {
+		return SignerBackendKeystore
+	}
+	return s.Backend
+}
+
 // validateSignerConfig validates a single signer configuration
 func validateSignerConfig(role string, signer SignerConfig) error {
-	if signer.PrivateKeyPath == "" && signer.KeystorePath == "" {
-		return fmt.Errorf("signer '%s': either private_key or keystore must be provided", role)
+	if signer.Mnemonic != "" && signer.SeedFile != "" {
+		return fmt.Errorf("signer '%s': only one of mnemonic or seed_file should be provided, not both", role)
 	}
 
-	if signer.PrivateKeyPath != "" && signer.KeystorePath != "" {
-		return fmt.Errorf("signer '%s': only one authentication method should be provided: either private_key or keystore, not both", role)
-	}
+	backend := signer.ResolvedBackend()
+
+	switch backend {
+	case SignerBackendKeystore:
+		if signer.PrivateKeyPath == "" && signer.KeystorePath == "" && signer.Mnemonic == "" && signer.SeedFile == "" {
+			return fmt.Errorf("signer '%s': one of private_key, keystore, mnemonic, or seed_file must be provided", role)
+		}
+
+		if signer.PrivateKeyPath != "" && signer.KeystorePath != "" {
+			return fmt.Errorf("signer '%s': only one authentication method should be provided: either private_key or keystore, not both", role)
+		}
 
-	if signer.KeystorePath != "" && signer.KeystorePassword == "" {
-		return fmt.Errorf("signer '%s': keystore_password is required when using keystore", role)
+		if signer.KeystorePath != "" && signer.KeystorePassword == "" {
+			return fmt.Errorf("signer '%s': keystore_password is required when using keystore", role)
+		}
+	case SignerBackendExternal:
+		if signer.ExternalURL == "" {
+			return fmt.Errorf("signer '%s': external_url is required when backend is 'external'", role)
+		}
+		if signer.ExternalAddress == "" {
+			return fmt.Errorf("signer '%s': external_address is required when backend is 'external'", role)
+		}
+		if !common.IsHexAddress(signer.ExternalAddress) {
+			return fmt.Errorf("signer '%s': invalid external_address: %s", role, signer.ExternalAddress)
+		}
+	case SignerBackendLedger:
+		if signer.LedgerDerivationPath == "" {
+			return fmt.Errorf("signer '%s': ledger_derivation_path is required when backend is 'ledger'", role)
+		}
+	case SignerBackendAWSKMS, SignerBackendGCPKMS:
+		if signer.KMSKeyID == "" {
+			return fmt.Errorf("signer '%s': kms_key_id is required when backend is %q", role, backend)
+		}
+	case SignerBackendVault:
+		if signer.VaultAddr == "" {
+			return fmt.Errorf("signer '%s': vault_addr is required when backend is 'vault'", role)
+		}
+		if signer.VaultKeyName == "" {
+			return fmt.Errorf("signer '%s': vault_key_name is required when backend is 'vault'", role)
+		}
+	default:
+		return fmt.Errorf("signer '%s': unknown backend %q (supported: keystore, external, ledger, aws-kms, gcp-kms, vault)", role, signer.Backend)
 	}
 
 	return nil
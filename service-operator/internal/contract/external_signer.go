@@ -0,0 +1,55 @@
+package contract
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/storacha/filecoin-services/go/eip712"
+)
+
+// externalSigner delegates EIP-712 signing to an external JSON-RPC signer
+// such as Clef or Frame, calling account_signTypedData instead of holding a
+// private key in this process.
+type externalSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// NewExternalSigner dials a Clef-style JSON-RPC endpoint and returns a
+// TypedDataSigner that signs on behalf of address via account_signTypedData.
+// If token is non-empty, it's sent as a Bearer token on every request, for
+// remote custodial signing daemons that require authentication (a local
+// Clef/Frame instance typically doesn't).
+func NewExternalSigner(ctx context.Context, url string, address common.Address, token string) (TypedDataSigner, error) {
+	client, err := dialExternalSigner(ctx, url, token)
+	if err != nil {
+		return nil, fmt.Errorf("dialing external signer at %s: %w", url, err)
+	}
+	return &externalSigner{client: client, address: address}, nil
+}
+
+func (s *externalSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *externalSigner) SignTypedData(typedData apitypes.TypedData) (*eip712.AuthSignature, error) {
+	var sigHex hexutil.Bytes
+	if err := s.client.Call(&sigHex, "account_signTypedData", s.address, typedData); err != nil {
+		return nil, fmt.Errorf("calling account_signTypedData on external signer: %w", err)
+	}
+	if len(sigHex) != 65 {
+		return nil, fmt.Errorf("unexpected signature length from external signer: got %d, want 65", len(sigHex))
+	}
+
+	digest, err := eip712.Digest(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	return authSignatureFromRSV([]byte(sigHex), digest[:], s.address), nil
+}
@@ -0,0 +1,33 @@
+package contract
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// dialExternalSigner dials a Clef/Frame-style JSON-RPC endpoint, used by both
+// NewExternalSigner and NewRemoteTxSigner. When token is non-empty, it dials
+// over plain HTTP with a client that attaches the token as a Bearer
+// Authorization header on every request, since rpc.DialContext has no way to
+// authenticate to a remote signer that isn't a bare local Clef/Frame
+// instance.
+func dialExternalSigner(ctx context.Context, url, token string) (*rpc.Client, error) {
+	if token == "" {
+		return rpc.DialContext(ctx, url)
+	}
+	return rpc.DialHTTPWithClient(url, &http.Client{Transport: &bearerRoundTripper{token: token}})
+}
+
+// bearerRoundTripper attaches a Bearer Authorization header to every
+// request, leaving everything else to http.DefaultTransport.
+type bearerRoundTripper struct {
+	token string
+}
+
+func (t *bearerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
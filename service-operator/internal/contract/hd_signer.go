@@ -0,0 +1,84 @@
+package contract
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/config"
+)
+
+// DefaultHDDerivationPath is used when a SignerConfig enables HD derivation
+// (via mnemonic or seed_file) but does not set an explicit derivation_path.
+// It matches accounts.DefaultBaseDerivationPath ("m/44'/60'/0'/0").
+const DefaultHDDerivationPath = "m/44'/60'/0'/0"
+
+// hdSeed returns the raw BIP-32 seed for a signer configured with either a
+// mnemonic phrase or a seed file.
+func hdSeed(signerConfig config.SignerConfig) ([]byte, error) {
+	if signerConfig.Mnemonic != "" {
+		if !bip39.IsMnemonicValid(signerConfig.Mnemonic) {
+			return nil, fmt.Errorf("invalid mnemonic")
+		}
+		return bip39.NewSeed(signerConfig.Mnemonic, ""), nil
+	}
+
+	raw, err := os.ReadFile(signerConfig.SeedFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading seed file: %w", err)
+	}
+	seed, err := hex.DecodeString(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("decoding seed file as hex: %w", err)
+	}
+	return seed, nil
+}
+
+// DeriveHDKey derives the private key for accountIndex under the role's
+// configured mnemonic/seed and derivation_path (or DefaultHDDerivationPath),
+// following the same m/44'/60'/0'/0/{index} convention as
+// accounts.DefaultBaseDerivationPath.
+func DeriveHDKey(signerConfig config.SignerConfig, accountIndex uint32) (*ecdsa.PrivateKey, error) {
+	seed, err := hdSeed(signerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("loading HD seed: %w", err)
+	}
+
+	basePath := signerConfig.DerivationPath
+	if basePath == "" {
+		basePath = DefaultHDDerivationPath
+	}
+
+	fullPath, err := accounts.ParseDerivationPath(fmt.Sprintf("%s/%d", basePath, accountIndex))
+	if err != nil {
+		return nil, fmt.Errorf("parsing derivation path: %w", err)
+	}
+
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, fmt.Errorf("deriving master key: %w", err)
+	}
+
+	key := master
+	for _, index := range fullPath {
+		key, err = key.Derive(index)
+		if err != nil {
+			return nil, fmt.Errorf("deriving path segment %d: %w", index, err)
+		}
+	}
+
+	rawKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, fmt.Errorf("extracting EC private key: %w", err)
+	}
+
+	return crypto.ToECDSA(rawKey.Serialize())
+}
@@ -0,0 +1,93 @@
+//go:build kms
+
+package kmssigner
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+)
+
+func init() {
+	contract.AWSKMSSignerFactory = func(ctx context.Context, keyID string) (contract.TxSigner, error) {
+		return OpenAWS(ctx, keyID)
+	}
+}
+
+// AWSSigner signs transactions with an AWS KMS asymmetric ECDSA_SECG_P256K1
+// key, never exposing the private key material outside of KMS.
+type AWSSigner struct {
+	client *kms.Client
+	keyID  string
+	pubKey *ecdsa.PublicKey
+	addr   common.Address
+}
+
+// OpenAWS loads the default AWS SDK config (respecting AWS_PROFILE,
+// AWS_REGION, and the standard credential chain) and fetches keyID's public
+// key, so the signer's address is known up front without needing a
+// signature first.
+func OpenAWS(ctx context.Context, keyID string) (*AWSSigner, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	client := kms.NewFromConfig(cfg)
+
+	out, err := client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: &keyID})
+	if err != nil {
+		return nil, fmt.Errorf("fetching public key for KMS key %s: %w", keyID, err)
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(out.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key for KMS key %s: %w", keyID, err)
+	}
+	ecdsaPub, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("KMS key %s is not an ECDSA key", keyID)
+	}
+
+	return &AWSSigner{
+		client: client,
+		keyID:  keyID,
+		pubKey: ecdsaPub,
+		addr:   pubkeyToAddress(ecdsaPub),
+	}, nil
+}
+
+func (s *AWSSigner) Address() common.Address {
+	return s.addr
+}
+
+func (s *AWSSigner) SignTx(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	digest := signer.Hash(tx).Bytes()
+
+	out, err := s.client.Sign(ctx, &kms.SignInput{
+		KeyId:            &s.keyID,
+		Message:          digest,
+		MessageType:      kmstypes.MessageTypeDigest,
+		SigningAlgorithm: kmstypes.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signing with KMS key %s: %w", s.keyID, err)
+	}
+
+	sig, err := recoverableSignature(out.Signature, digest, s.pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("recovering signature from KMS key %s: %w", s.keyID, err)
+	}
+
+	return tx.WithSignature(signer, sig)
+}
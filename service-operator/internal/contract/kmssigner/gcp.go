@@ -0,0 +1,99 @@
+//go:build kms
+
+package kmssigner
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+
+	kms "cloud.google.com/go/kms/apiv1"
+	kmspb "cloud.google.com/go/kms/apiv1/kmspb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+)
+
+func init() {
+	contract.GCPKMSSignerFactory = func(ctx context.Context, keyResourceName string) (contract.TxSigner, error) {
+		return OpenGCP(ctx, keyResourceName)
+	}
+}
+
+// GCPSigner signs transactions with a GCP Cloud KMS asymmetric
+// EC_SIGN_SECP256K1_SHA256 key, never exposing the private key material
+// outside of KMS.
+type GCPSigner struct {
+	client          *kms.KeyManagementClient
+	keyResourceName string
+	pubKey          *ecdsa.PublicKey
+	addr            common.Address
+}
+
+// OpenGCP connects to Cloud KMS using application-default credentials and
+// fetches keyResourceName's public key, so the signer's address is known up
+// front without needing a signature first. keyResourceName is a full
+// CryptoKeyVersion resource name, e.g.
+// "projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1".
+func OpenGCP(ctx context.Context, keyResourceName string) (*GCPSigner, error) {
+	client, err := kms.NewKeyManagementClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating Cloud KMS client: %w", err)
+	}
+
+	resp, err := client.GetPublicKey(ctx, &kmspb.GetPublicKeyRequest{Name: keyResourceName})
+	if err != nil {
+		return nil, fmt.Errorf("fetching public key for KMS key %s: %w", keyResourceName, err)
+	}
+
+	block, _ := pem.Decode([]byte(resp.Pem))
+	if block == nil {
+		return nil, fmt.Errorf("decoding PEM public key for KMS key %s", keyResourceName)
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key for KMS key %s: %w", keyResourceName, err)
+	}
+	ecdsaPub, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("KMS key %s is not an ECDSA key", keyResourceName)
+	}
+
+	return &GCPSigner{
+		client:          client,
+		keyResourceName: keyResourceName,
+		pubKey:          ecdsaPub,
+		addr:            pubkeyToAddress(ecdsaPub),
+	}, nil
+}
+
+func (s *GCPSigner) Address() common.Address {
+	return s.addr
+}
+
+// SignTx asks Cloud KMS to sign tx's already-hashed digest directly; the
+// digest field name refers to the algorithm the key uses (SHA256), not an
+// instruction to hash the input again.
+func (s *GCPSigner) SignTx(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	digest := signer.Hash(tx).Bytes()
+
+	resp, err := s.client.AsymmetricSign(ctx, &kmspb.AsymmetricSignRequest{
+		Name:   s.keyResourceName,
+		Digest: &kmspb.Digest{Digest: &kmspb.Digest_Sha256{Sha256: digest}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signing with KMS key %s: %w", s.keyResourceName, err)
+	}
+
+	sig, err := recoverableSignature(resp.Signature, digest, s.pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("recovering signature from KMS key %s: %w", s.keyResourceName, err)
+	}
+
+	return tx.WithSignature(signer, sig)
+}
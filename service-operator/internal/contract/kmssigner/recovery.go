@@ -0,0 +1,82 @@
+//go:build kms
+
+// Package kmssigner implements contract.TxSigner backed by AWS KMS and GCP
+// Cloud KMS asymmetric ECDSA (secp256k1) signing keys. It is only compiled
+// into binaries built with `-tags kms`, keeping the AWS/GCP SDK dependencies
+// out of ordinary builds.
+package kmssigner
+
+import (
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// derSignature is the ASN.1 structure both AWS KMS and GCP Cloud KMS return
+// for an asymmetric ECDSA signature: the raw (r, s) pair with no recovery id.
+type derSignature struct {
+	R, S *big.Int
+}
+
+// secp256k1HalfOrder is half of the secp256k1 curve order, used to normalize
+// s into its lower half per EIP-2 / go-ethereum's canonical signature form.
+var secp256k1HalfOrder = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
+// recoverableSignature parses a DER-encoded ECDSA signature as returned by a
+// KMS and turns it into the 65-byte [R || S || V] form go-ethereum expects,
+// by brute-forcing the recovery id: a KMS signature carries no v, so both
+// candidates are tried against digest and the one that recovers pubKey wins.
+func recoverableSignature(der []byte, digest []byte, pubKey *ecdsa.PublicKey) ([]byte, error) {
+	var sig derSignature
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, fmt.Errorf("parsing DER signature: %w", err)
+	}
+
+	// Normalize s to the lower half of the curve order; KMS does not
+	// guarantee this, but go-ethereum's recovery and most chains reject the
+	// upper half as non-canonical.
+	s := sig.S
+	if s.Cmp(secp256k1HalfOrder) > 0 {
+		s = new(big.Int).Sub(crypto.S256().Params().N, s)
+	}
+
+	rBytes := leftPadBytes(sig.R.Bytes(), 32)
+	sBytes := leftPadBytes(s.Bytes(), 32)
+
+	wantAddr := crypto.PubkeyToAddress(*pubKey)
+	for recID := byte(0); recID < 2; recID++ {
+		candidate := make([]byte, 65)
+		copy(candidate[0:32], rBytes)
+		copy(candidate[32:64], sBytes)
+		candidate[64] = recID
+
+		recovered, err := crypto.SigToPub(digest, candidate)
+		if err != nil {
+			continue
+		}
+		if crypto.PubkeyToAddress(*recovered) == wantAddr {
+			return candidate, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not derive recovery id: neither candidate signature recovers %s", wantAddr)
+}
+
+// pubkeyToAddress derives the Ethereum address for an ECDSA public key
+// fetched from a KMS, which never exposes the corresponding private key.
+func pubkeyToAddress(pub *ecdsa.PublicKey) common.Address {
+	return crypto.PubkeyToAddress(*pub)
+}
+
+func leftPadBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
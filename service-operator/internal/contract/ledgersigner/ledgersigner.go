@@ -0,0 +1,111 @@
+//go:build ledger
+
+// Package ledgersigner implements contract.TypedDataSigner backed by a
+// Ledger hardware wallet, reached via go-ethereum's accounts/usbwallet. It is
+// only compiled into binaries built with `-tags ledger`, keeping the
+// USB/HID dependency out of ordinary builds.
+package ledgersigner
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/storacha/filecoin-services/go/eip712"
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+)
+
+func init() {
+	contract.LedgerSignerFactory = func(derivationPath string) (contract.TypedDataSigner, error) {
+		return Open(derivationPath)
+	}
+	contract.LedgerTxSignerFactory = func(derivationPath string) (contract.TxSigner, error) {
+		return Open(derivationPath)
+	}
+}
+
+// Signer signs EIP-712 typed data with a key held on a connected Ledger
+// device at a fixed derivation path.
+type Signer struct {
+	wallet  accounts.Wallet
+	account accounts.Account
+}
+
+// Open connects to the first available Ledger device and derives the account
+// at derivationPath (e.g. "m/44'/60'/0'/0/0").
+func Open(derivationPath string) (*Signer, error) {
+	hub, err := usbwallet.NewLedgerHub()
+	if err != nil {
+		return nil, fmt.Errorf("opening USB hub: %w", err)
+	}
+
+	wallets := hub.Wallets()
+	if len(wallets) == 0 {
+		return nil, fmt.Errorf("no Ledger device found")
+	}
+
+	wallet := wallets[0]
+	if err := wallet.Open(""); err != nil {
+		return nil, fmt.Errorf("opening Ledger wallet: %w", err)
+	}
+
+	path, err := accounts.ParseDerivationPath(derivationPath)
+	if err != nil {
+		return nil, fmt.Errorf("parsing derivation path %q: %w", derivationPath, err)
+	}
+
+	account, err := wallet.Derive(path, true)
+	if err != nil {
+		return nil, fmt.Errorf("deriving account at %q: %w", derivationPath, err)
+	}
+
+	return &Signer{wallet: wallet, account: account}, nil
+}
+
+func (s *Signer) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *Signer) SignTypedData(typedData apitypes.TypedData) (*eip712.AuthSignature, error) {
+	digest, err := eip712.Digest(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := s.wallet.SignData(s.account, accounts.MimetypeTypedData, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("signing on Ledger device: %w", err)
+	}
+
+	v := sig[64]
+	if v < 27 {
+		v += 27
+	}
+
+	return &eip712.AuthSignature{
+		Signature:  sig,
+		V:          v,
+		R:          common.BytesToHash(sig[0:32]),
+		S:          common.BytesToHash(sig[32:64]),
+		SignedData: digest[:],
+		Signer:     s.account.Address,
+	}, nil
+}
+
+// SignTx signs tx on the connected Ledger device, implementing
+// contract.TxSigner so settlement and other on-chain operations can be
+// driven from a hardware wallet instead of a private key held in process
+// memory.
+func (s *Signer) SignTx(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	signed, err := s.wallet.SignTx(s.account, tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("signing transaction on Ledger device: %w", err)
+	}
+	return signed, nil
+}
@@ -0,0 +1,181 @@
+package contract
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Multicall3Address is the canonical Multicall3 deployment address, identical
+// across every EVM chain it's been deployed to (including Filecoin mainnet
+// and calibration) because it's published via a deterministic deployer.
+// https://www.multicall3.com/
+const Multicall3Address = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+// multicall3ABI covers only the one method this package calls; there is no
+// generated binding for Multicall3 in this repo, so calldata is packed by
+// hand the same way QueryTokenDecimals falls back to a raw selector when no
+// generated caller exists.
+const multicall3ABI = `[{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3[]","name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"}]`
+
+// Multicall3Call is one entry of the batch passed to PackAggregate3.
+type Multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// IsMulticall3Deployed reports whether Multicall3Address has contract code
+// on the chain client is connected to, so callers can fall back to
+// sequential transactions when it isn't.
+func IsMulticall3Deployed(ctx context.Context, client *ethclient.Client) (bool, error) {
+	code, err := client.CodeAt(ctx, common.HexToAddress(Multicall3Address), nil)
+	if err != nil {
+		return false, fmt.Errorf("checking for Multicall3 code at %s: %w", Multicall3Address, err)
+	}
+	return len(code) > 0, nil
+}
+
+// PackAggregate3 encodes an aggregate3(Call3[]) call against calls, each
+// entry of which should have AllowFailure set so one reverting call (e.g. a
+// provider that's already approved) doesn't abort the rest of the batch.
+func PackAggregate3(calls []Multicall3Call) ([]byte, error) {
+	parsed, err := abi.JSON(strings.NewReader(multicall3ABI))
+	if err != nil {
+		return nil, fmt.Errorf("parsing Multicall3 ABI: %w", err)
+	}
+
+	type call3 struct {
+		Target       common.Address
+		AllowFailure bool
+		CallData     []byte
+	}
+	args := make([]call3, len(calls))
+	for i, c := range calls {
+		args[i] = call3{Target: c.Target, AllowFailure: c.AllowFailure, CallData: c.CallData}
+	}
+
+	data, err := parsed.Pack("aggregate3", args)
+	if err != nil {
+		return nil, fmt.Errorf("packing aggregate3 calldata: %w", err)
+	}
+	return data, nil
+}
+
+// Multicall3CallResult is the decoded per-call outcome from aggregate3's
+// (bool success, bytes returnData)[] return value, in the same order as the
+// Multicall3Call slice that produced it.
+type Multicall3CallResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// decodeAggregate3Result unpacks aggregate3's (bool success, bytes
+// returnData)[] return value out of data.
+func decodeAggregate3Result(data []byte) ([]Multicall3CallResult, error) {
+	parsed, err := abi.JSON(strings.NewReader(multicall3ABI))
+	if err != nil {
+		return nil, fmt.Errorf("parsing Multicall3 ABI: %w", err)
+	}
+
+	outputs, err := parsed.Methods["aggregate3"].Outputs.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding aggregate3 result: %w", err)
+	}
+
+	type result struct {
+		Success    bool
+		ReturnData []byte
+	}
+	raw, ok := outputs[0].([]result)
+	if !ok {
+		return nil, fmt.Errorf("unexpected aggregate3 result type %T", outputs[0])
+	}
+
+	decoded := make([]Multicall3CallResult, len(raw))
+	for i, r := range raw {
+		decoded[i] = Multicall3CallResult{Success: r.Success, ReturnData: r.ReturnData}
+	}
+	return decoded, nil
+}
+
+// SendMulticall3 signs and broadcasts a single transaction invoking
+// aggregate3(calls) via signer, waiting for its receipt the same way every
+// other state-changing command does. Because a mined transaction's return
+// data isn't available from a standard receipt, the per-call success flags
+// are obtained by dry-running the same calldata via eth_call immediately
+// before broadcasting; callers must still treat the transaction itself
+// reverting (receipt.Status == 0) as every call having failed, since that
+// can't happen to an aggregate3 call made with every entry's AllowFailure
+// set, but would otherwise invalidate the dry-run results.
+func SendMulticall3(ctx context.Context, client *ethclient.Client, signer TxSigner, chainID *big.Int, calls []Multicall3Call) (*types.Receipt, []Multicall3CallResult, error) {
+	data, err := PackAggregate3(calls)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	from := signer.Address()
+	to := common.HexToAddress(Multicall3Address)
+
+	simResult, err := client.PendingCallContract(ctx, ethereum.CallMsg{From: from, To: &to, Data: data})
+	if err != nil {
+		return nil, nil, fmt.Errorf("simulating aggregate3: %w", err)
+	}
+	callResults, err := decodeAggregate3Result(simResult)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(callResults) != len(calls) {
+		return nil, nil, fmt.Errorf("aggregate3 returned %d results for %d calls", len(callResults), len(calls))
+	}
+
+	nonce, err := client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting pending nonce: %w", err)
+	}
+	gasTipCap, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("suggesting gas tip cap: %w", err)
+	}
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting latest header: %w", err)
+	}
+	gasFeeCap := new(big.Int).Add(gasTipCap, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+
+	gasLimit, err := client.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &to, Data: data})
+	if err != nil {
+		return nil, nil, fmt.Errorf("estimating gas for aggregate3: %w", err)
+	}
+
+	unsignedTx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       gasLimit,
+		To:        &to,
+		Data:      data,
+	})
+
+	signedTx, err := signer.SignTx(ctx, chainID, unsignedTx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("signing aggregate3 transaction: %w", err)
+	}
+	if err := client.SendTransaction(ctx, signedTx); err != nil {
+		return nil, nil, fmt.Errorf("broadcasting aggregate3 transaction: %w", err)
+	}
+
+	receipt, err := WaitForTransaction(ctx, client, signedTx.Hash())
+	if err != nil {
+		return nil, nil, err
+	}
+	return receipt, callResults, nil
+}
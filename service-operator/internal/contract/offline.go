@@ -0,0 +1,143 @@
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxEnvelope is the file format written by a command's --offline mode and
+// consumed by `service-operator tx sign` / `service-operator tx submit`. It
+// carries an unsigned (or, once signed, signed) transaction alongside enough
+// human-readable context that a reviewer on an air-gapped host or in a
+// multisig ceremony can tell what they're about to sign without decoding
+// calldata by hand.
+type TxEnvelope struct {
+	ChainID   string          `json:"chainId"`
+	From      common.Address  `json:"from"`
+	Action    string          `json:"action"`
+	To        *common.Address `json:"to,omitempty"`
+	Nonce     uint64          `json:"nonce"`
+	Value     string          `json:"value"`
+	GasLimit  uint64          `json:"gasLimit"`
+	GasFeeCap string          `json:"gasFeeCap,omitempty"`
+	GasTipCap string          `json:"gasTipCap,omitempty"`
+	GasPrice  string          `json:"gasPrice,omitempty"`
+	Data      string          `json:"data"`
+	RawTx     string          `json:"rawTx"`
+	Signed    bool            `json:"signed"`
+}
+
+// NewTxEnvelope describes tx (as built by a bound contract call through
+// CreateOfflineTransactor) as a TxEnvelope for offline review and signing.
+// action is a short human-readable summary of what the transaction does,
+// e.g. "AddApprovedProvider(providerId=42)".
+func NewTxEnvelope(tx *types.Transaction, chainID *big.Int, from common.Address, action string) (*TxEnvelope, error) {
+	rawTx, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("encoding transaction: %w", err)
+	}
+
+	env := &TxEnvelope{
+		ChainID:  chainID.String(),
+		From:     from,
+		Action:   action,
+		To:       tx.To(),
+		Nonce:    tx.Nonce(),
+		Value:    tx.Value().String(),
+		GasLimit: tx.Gas(),
+		Data:     hexutil.Encode(tx.Data()),
+		RawTx:    hexutil.Encode(rawTx),
+	}
+	if feeCap := tx.GasFeeCap(); feeCap != nil {
+		env.GasFeeCap = feeCap.String()
+	}
+	if tipCap := tx.GasTipCap(); tipCap != nil {
+		env.GasTipCap = tipCap.String()
+	}
+	if tx.Type() == types.LegacyTxType {
+		env.GasPrice = tx.GasPrice().String()
+	}
+	return env, nil
+}
+
+// Transaction decodes the envelope's RawTx back into a *types.Transaction.
+func (e *TxEnvelope) Transaction() (*types.Transaction, error) {
+	raw, err := hexutil.Decode(e.RawTx)
+	if err != nil {
+		return nil, fmt.Errorf("decoding rawTx: %w", err)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("unmarshaling transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// ChainIDInt parses the envelope's ChainID field.
+func (e *TxEnvelope) ChainIDInt() (*big.Int, error) {
+	chainID, ok := new(big.Int).SetString(e.ChainID, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid chainId %q in envelope", e.ChainID)
+	}
+	return chainID, nil
+}
+
+// WriteTxEnvelope writes env to path as indented JSON.
+func WriteTxEnvelope(path string, env *TxEnvelope) error {
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding envelope: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing envelope to %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadTxEnvelope reads a TxEnvelope previously written by WriteTxEnvelope.
+func ReadTxEnvelope(path string) (*TxEnvelope, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading envelope from %s: %w", path, err)
+	}
+	var env TxEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("parsing envelope %s: %w", path, err)
+	}
+	return &env, nil
+}
+
+// CapturedTx holds the transaction captured by CreateOfflineTransactor's
+// Signer callback once the bound contract call it was passed to has run.
+type CapturedTx struct {
+	Tx *types.Transaction
+}
+
+// CreateOfflineTransactor builds a *bind.TransactOpts that captures the
+// fully-populated (nonce, gas, calldata) transaction a bound contract method
+// would otherwise sign and broadcast, without ever signing or sending it.
+// The generated binding still estimates gas and assigns a nonce against the
+// RPC endpoint exactly as it would for a live call; only the final
+// sign-and-send step is replaced with a capture, so the resulting
+// CapturedTx.Tx is ready to wrap in a TxEnvelope via NewTxEnvelope.
+func CreateOfflineTransactor(ctx context.Context, from common.Address) (*bind.TransactOpts, *CapturedTx) {
+	captured := &CapturedTx{}
+	auth := &bind.TransactOpts{
+		From:    from,
+		Context: ctx,
+		NoSend:  true,
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			captured.Tx = tx
+			return tx, nil
+		},
+	}
+	return auth, captured
+}
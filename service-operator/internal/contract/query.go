@@ -16,6 +16,14 @@ const (
 	// ERC20DecimalsSelector is the method selector for ERC20.decimals()
 	// This is the first 4 bytes of Keccak256("decimals()") = 0x313ce567...
 	ERC20DecimalsSelector = "0x313ce567"
+
+	// DomainSeparatorSelector is the method selector for DOMAIN_SEPARATOR()
+	// This is the first 4 bytes of Keccak256("DOMAIN_SEPARATOR()") = 0x3644e515...
+	DomainSeparatorSelector = "0x3644e515"
+
+	// ERC20BalanceOfSelector is the method selector for ERC20.balanceOf(address)
+	// This is the first 4 bytes of Keccak256("balanceOf(address)") = 0x70a08231...
+	ERC20BalanceOfSelector = "0x70a08231"
 )
 
 // ServicePricing contains pricing information from the FilecoinWarmStorageService contract
@@ -86,3 +94,62 @@ func QueryTokenDecimals(ctx context.Context, rpcURL string, tokenAddress common.
 
 	return decimals, nil
 }
+
+// QueryTokenBalance queries an ERC20 token contract for account's balance,
+// the same raw-selector way QueryTokenDecimals queries decimals().
+func QueryTokenBalance(ctx context.Context, rpcURL string, tokenAddress, account common.Address) (*big.Int, error) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to RPC: %w", err)
+	}
+	defer client.Close()
+
+	data := append(common.FromHex(ERC20BalanceOfSelector), common.LeftPadBytes(account.Bytes(), 32)...)
+
+	msg := ethereum.CallMsg{
+		To:   &tokenAddress,
+		Data: data,
+	}
+
+	result, err := client.CallContract(ctx, msg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("calling balanceOf(%s): %w", account.Hex(), err)
+	}
+	if len(result) != 32 {
+		return nil, fmt.Errorf("unexpected result length: got %d, expected 32", len(result))
+	}
+
+	return new(big.Int).SetBytes(result), nil
+}
+
+// QueryDomainSeparator queries a contract's DOMAIN_SEPARATOR() view function,
+// which returns the EIP-712 domain separator it actually checks signatures
+// against on-chain. Callers should cross-check this against
+// eip712.Domain.Hash() (via eip712.NewVerifiedTypedDataBuilder) before
+// signing anything, to catch a wrong-network or upgraded-contract mistake
+// before it produces a signature nobody can use.
+func QueryDomainSeparator(ctx context.Context, rpcURL string, contractAddress common.Address) (common.Hash, error) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("connecting to RPC: %w", err)
+	}
+	defer client.Close()
+
+	data := common.FromHex(DomainSeparatorSelector)
+
+	msg := ethereum.CallMsg{
+		To:   &contractAddress,
+		Data: data,
+	}
+
+	result, err := client.CallContract(ctx, msg, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("calling DOMAIN_SEPARATOR(): %w", err)
+	}
+
+	if len(result) != 32 {
+		return common.Hash{}, fmt.Errorf("unexpected result length: got %d, expected 32", len(result))
+	}
+
+	return common.BytesToHash(result), nil
+}
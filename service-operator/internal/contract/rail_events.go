@@ -0,0 +1,131 @@
+package contract
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// railEventsABI is the minimal ABI fragment for the Payments contract's rail
+// lifecycle events, parsed once at init so a daemon can subscribe to and
+// decode them without a full generated contract binding.
+var railEventsABI abi.ABI
+
+func init() {
+	const railEventsJSON = `[
+		{"name":"RailCreated","type":"event","anonymous":false,"inputs":[
+			{"name":"railId","type":"uint256","indexed":true},
+			{"name":"payer","type":"address","indexed":true},
+			{"name":"payee","type":"address","indexed":true}
+		]},
+		{"name":"RailSettled","type":"event","anonymous":false,"inputs":[
+			{"name":"railId","type":"uint256","indexed":true},
+			{"name":"totalSettledAmount","type":"uint256","indexed":false},
+			{"name":"totalNetPayeeAmount","type":"uint256","indexed":false},
+			{"name":"totalOperatorCommission","type":"uint256","indexed":false},
+			{"name":"settledUpToEpoch","type":"uint256","indexed":false}
+		]},
+		{"name":"RailTerminated","type":"event","anonymous":false,"inputs":[
+			{"name":"railId","type":"uint256","indexed":true},
+			{"name":"endEpoch","type":"uint256","indexed":false}
+		]}
+	]`
+
+	parsed, err := abi.JSON(strings.NewReader(railEventsJSON))
+	if err != nil {
+		panic(fmt.Sprintf("parsing rail events ABI: %v", err))
+	}
+	railEventsABI = parsed
+}
+
+// RailEventTopics returns the topic0 hashes for the rail lifecycle events a
+// watcher should subscribe to: RailCreated, RailSettled, and RailTerminated.
+func RailEventTopics() []common.Hash {
+	return []common.Hash{
+		railEventsABI.Events["RailCreated"].ID,
+		railEventsABI.Events["RailSettled"].ID,
+		railEventsABI.Events["RailTerminated"].ID,
+	}
+}
+
+// RailCreatedEvent is the decoded form of a Payments.RailCreated log.
+type RailCreatedEvent struct {
+	RailID *big.Int
+	Payer  common.Address
+	Payee  common.Address
+}
+
+// RailSettledEvent is the decoded form of a Payments.RailSettled log.
+type RailSettledEvent struct {
+	RailID                  *big.Int
+	TotalSettledAmount      *big.Int
+	TotalNetPayeeAmount     *big.Int
+	TotalOperatorCommission *big.Int
+	SettledUpToEpoch        *big.Int
+}
+
+// RailTerminatedEvent is the decoded form of a Payments.RailTerminated log.
+type RailTerminatedEvent struct {
+	RailID   *big.Int
+	EndEpoch *big.Int
+}
+
+// ParseRailCreatedEvent decodes log as a RailCreated event.
+func ParseRailCreatedEvent(log types.Log) (*RailCreatedEvent, error) {
+	if len(log.Topics) != 4 {
+		return nil, fmt.Errorf("unexpected topic count for RailCreated: got %d, want 4", len(log.Topics))
+	}
+	return &RailCreatedEvent{
+		RailID: new(big.Int).SetBytes(log.Topics[1].Bytes()),
+		Payer:  common.BytesToAddress(log.Topics[2].Bytes()),
+		Payee:  common.BytesToAddress(log.Topics[3].Bytes()),
+	}, nil
+}
+
+// ParseRailSettledEvent decodes log as a RailSettled event.
+func ParseRailSettledEvent(log types.Log) (*RailSettledEvent, error) {
+	if len(log.Topics) != 2 {
+		return nil, fmt.Errorf("unexpected topic count for RailSettled: got %d, want 2", len(log.Topics))
+	}
+
+	var event struct {
+		TotalSettledAmount      *big.Int
+		TotalNetPayeeAmount     *big.Int
+		TotalOperatorCommission *big.Int
+		SettledUpToEpoch        *big.Int
+	}
+	if err := railEventsABI.UnpackIntoInterface(&event, "RailSettled", log.Data); err != nil {
+		return nil, fmt.Errorf("unpacking RailSettled data: %w", err)
+	}
+
+	return &RailSettledEvent{
+		RailID:                  new(big.Int).SetBytes(log.Topics[1].Bytes()),
+		TotalSettledAmount:      event.TotalSettledAmount,
+		TotalNetPayeeAmount:     event.TotalNetPayeeAmount,
+		TotalOperatorCommission: event.TotalOperatorCommission,
+		SettledUpToEpoch:        event.SettledUpToEpoch,
+	}, nil
+}
+
+// ParseRailTerminatedEvent decodes log as a RailTerminated event.
+func ParseRailTerminatedEvent(log types.Log) (*RailTerminatedEvent, error) {
+	if len(log.Topics) != 2 {
+		return nil, fmt.Errorf("unexpected topic count for RailTerminated: got %d, want 2", len(log.Topics))
+	}
+
+	var event struct {
+		EndEpoch *big.Int
+	}
+	if err := railEventsABI.UnpackIntoInterface(&event, "RailTerminated", log.Data); err != nil {
+		return nil, fmt.Errorf("unpacking RailTerminated data: %w", err)
+	}
+
+	return &RailTerminatedEvent{
+		RailID:   new(big.Int).SetBytes(log.Topics[1].Bytes()),
+		EndEpoch: event.EndEpoch,
+	}, nil
+}
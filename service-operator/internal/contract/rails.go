@@ -0,0 +1,137 @@
+package contract
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/registry"
+)
+
+// ActiveRail pairs a payment rail with the ServiceProviderRegistry identity
+// of its payee, as returned by EnumerateActiveProviderRails.
+type ActiveRail struct {
+	RailInfo *RailInfo
+
+	// ProviderName and ProviderID are empty/nil when the rail's payee isn't
+	// (or is no longer) a registered, active provider.
+	ProviderName string
+	ProviderID   *big.Int
+}
+
+// EnumerateRailsOptions controls how EnumerateActiveProviderRails paginates
+// the registry and parallelizes its per-provider rail lookups. The zero
+// value is valid and uses registry.Config's own defaults with no cache.
+type EnumerateRailsOptions struct {
+	// PageSize and Concurrency are forwarded to registry.Config to bound
+	// GetAllActiveProviders pagination and GetProvidersByIds fan-out.
+	PageSize    *big.Int
+	Concurrency int
+	// Cache, if non-nil, is consulted and populated by the registry lookup
+	// so repeated calls within its TTL skip re-fetching unchanged provider
+	// metadata.
+	Cache *registry.Cache
+}
+
+// EnumerateActiveProviderRails queries the ServiceProviderRegistry for every
+// active provider, then queries the Payments contract for every rail paid to
+// each provider's payee address. It's the rail-enumeration shared by
+// `payments status` and `payments watch`.
+//
+// Provider enumeration is paginated and providers are fetched concurrently
+// (see EnumerateRailsOptions); per-provider rail lookups are likewise fanned
+// out across a bounded worker pool rather than queried one at a time. A
+// failure to enumerate providers at all is returned as an error, but a
+// failure to query one provider's rails only logs a warning and is excluded
+// from the result, so one bad provider can't hide every other one.
+func EnumerateActiveProviderRails(ctx context.Context, rpcURL string, registryAddress, paymentsAddress, tokenAddress common.Address, opts EnumerateRailsOptions) ([]ActiveRail, error) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to RPC endpoint: %w", err)
+	}
+	defer client.Close()
+
+	views, errs := registry.EnumerateActiveProviders(ctx, client, registryAddress, registry.Config{
+		PageSize:    opts.PageSize,
+		Concurrency: opts.Concurrency,
+		Cache:       opts.Cache,
+	})
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = registry.DefaultConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+
+	var errWg sync.WaitGroup
+	errWg.Add(1)
+	go func() {
+		defer errWg.Done()
+		for err := range errs {
+			fmt.Fprintf(os.Stderr, "Warning: error enumerating active providers: %v\n", err)
+		}
+	}()
+
+	var mu sync.Mutex
+	var rails []ActiveRail
+	var wg sync.WaitGroup
+
+	for view := range views {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(v registry.ProviderView) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			providerRails, err := queryProviderRails(ctx, rpcURL, paymentsAddress, tokenAddress, v)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: error querying rails for payee %s: %v\n", v.Payee.Hex(), err)
+				return
+			}
+
+			mu.Lock()
+			rails = append(rails, providerRails...)
+			mu.Unlock()
+		}(view)
+	}
+	wg.Wait()
+	errWg.Wait()
+
+	if opts.Cache != nil {
+		if err := opts.Cache.Flush(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: error flushing provider cache: %v\n", err)
+		}
+	}
+
+	return rails, nil
+}
+
+// queryProviderRails fetches and labels every rail paid to v's payee
+// address.
+func queryProviderRails(ctx context.Context, rpcURL string, paymentsAddress, tokenAddress common.Address, v registry.ProviderView) ([]ActiveRail, error) {
+	summaries, err := QueryRailsForPayee(ctx, rpcURL, paymentsAddress, v.Payee, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var rails []ActiveRail
+	for _, summary := range summaries {
+		railInfo, err := QueryRailInfo(ctx, rpcURL, paymentsAddress, summary.RailId)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: error querying rail %s: %v\n", summary.RailId.String(), err)
+			continue
+		}
+
+		rails = append(rails, ActiveRail{
+			RailInfo:     railInfo,
+			ProviderName: v.Name,
+			ProviderID:   v.ProviderID,
+		})
+	}
+	return rails, nil
+}
@@ -0,0 +1,202 @@
+package contract
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// registryEventsABI is the minimal ABI fragment for the events `provider
+// watch` tails: the ServiceProviderRegistry's provider lifecycle events and
+// FilecoinWarmStorageService's approval events, parsed once at init so
+// watch can subscribe to and decode them without a full generated contract
+// binding, the same approach rail_events.go takes for the Payments
+// contract's rail events.
+var registryEventsABI abi.ABI
+
+func init() {
+	const registryEventsJSON = `[
+		{"name":"ProviderRegistered","type":"event","anonymous":false,"inputs":[
+			{"name":"providerId","type":"uint256","indexed":true},
+			{"name":"serviceProvider","type":"address","indexed":true},
+			{"name":"payee","type":"address","indexed":true}
+		]},
+		{"name":"ProviderInfoUpdated","type":"event","anonymous":false,"inputs":[
+			{"name":"providerId","type":"uint256","indexed":true},
+			{"name":"name","type":"string","indexed":false},
+			{"name":"description","type":"string","indexed":false}
+		]},
+		{"name":"ProviderRemoved","type":"event","anonymous":false,"inputs":[
+			{"name":"providerId","type":"uint256","indexed":true}
+		]},
+		{"name":"PayeeChanged","type":"event","anonymous":false,"inputs":[
+			{"name":"providerId","type":"uint256","indexed":true},
+			{"name":"oldPayee","type":"address","indexed":true},
+			{"name":"newPayee","type":"address","indexed":false}
+		]},
+		{"name":"ProviderApproved","type":"event","anonymous":false,"inputs":[
+			{"name":"providerId","type":"uint256","indexed":true}
+		]},
+		{"name":"ProviderApprovalRevoked","type":"event","anonymous":false,"inputs":[
+			{"name":"providerId","type":"uint256","indexed":true}
+		]}
+	]`
+
+	parsed, err := abi.JSON(strings.NewReader(registryEventsJSON))
+	if err != nil {
+		panic(fmt.Sprintf("parsing registry events ABI: %v", err))
+	}
+	registryEventsABI = parsed
+}
+
+// RegistryEventNames lists the events RegistryEventTopics returns topics
+// for, in the same order, so a caller can map a decoded topic0 back to a
+// human-readable event name.
+var RegistryEventNames = []string{
+	"ProviderRegistered",
+	"ProviderInfoUpdated",
+	"ProviderRemoved",
+	"PayeeChanged",
+	"ProviderApproved",
+	"ProviderApprovalRevoked",
+}
+
+// RegistryEventTopics returns the topic0 hashes for every event
+// `provider watch` subscribes to, in the same order as RegistryEventNames.
+func RegistryEventTopics() []common.Hash {
+	topics := make([]common.Hash, len(RegistryEventNames))
+	for i, name := range RegistryEventNames {
+		topics[i] = registryEventsABI.Events[name].ID
+	}
+	return topics
+}
+
+// ProviderRegisteredEvent is the decoded form of a
+// ServiceProviderRegistry.ProviderRegistered log.
+type ProviderRegisteredEvent struct {
+	ProviderID      *big.Int
+	ServiceProvider common.Address
+	Payee           common.Address
+}
+
+// ProviderInfoUpdatedEvent is the decoded form of a
+// ServiceProviderRegistry.ProviderInfoUpdated log.
+type ProviderInfoUpdatedEvent struct {
+	ProviderID  *big.Int
+	Name        string
+	Description string
+}
+
+// ProviderRemovedEvent is the decoded form of a
+// ServiceProviderRegistry.ProviderRemoved log.
+type ProviderRemovedEvent struct {
+	ProviderID *big.Int
+}
+
+// PayeeChangedEvent is the decoded form of a
+// ServiceProviderRegistry.PayeeChanged log.
+type PayeeChangedEvent struct {
+	ProviderID *big.Int
+	OldPayee   common.Address
+	NewPayee   common.Address
+}
+
+// ProviderApprovedEvent is the decoded form of a
+// FilecoinWarmStorageService.ProviderApproved log.
+type ProviderApprovedEvent struct {
+	ProviderID *big.Int
+}
+
+// ProviderApprovalRevokedEvent is the decoded form of a
+// FilecoinWarmStorageService.ProviderApprovalRevoked log.
+type ProviderApprovalRevokedEvent struct {
+	ProviderID *big.Int
+}
+
+// ParseProviderRegisteredEvent decodes log as a ProviderRegistered event.
+func ParseProviderRegisteredEvent(log types.Log) (*ProviderRegisteredEvent, error) {
+	if len(log.Topics) != 4 {
+		return nil, fmt.Errorf("unexpected topic count for ProviderRegistered: got %d, want 4", len(log.Topics))
+	}
+	return &ProviderRegisteredEvent{
+		ProviderID:      new(big.Int).SetBytes(log.Topics[1].Bytes()),
+		ServiceProvider: common.BytesToAddress(log.Topics[2].Bytes()),
+		Payee:           common.BytesToAddress(log.Topics[3].Bytes()),
+	}, nil
+}
+
+// ParseProviderInfoUpdatedEvent decodes log as a ProviderInfoUpdated event.
+func ParseProviderInfoUpdatedEvent(log types.Log) (*ProviderInfoUpdatedEvent, error) {
+	if len(log.Topics) != 2 {
+		return nil, fmt.Errorf("unexpected topic count for ProviderInfoUpdated: got %d, want 2", len(log.Topics))
+	}
+
+	var event struct {
+		Name        string
+		Description string
+	}
+	if err := registryEventsABI.UnpackIntoInterface(&event, "ProviderInfoUpdated", log.Data); err != nil {
+		return nil, fmt.Errorf("unpacking ProviderInfoUpdated data: %w", err)
+	}
+
+	return &ProviderInfoUpdatedEvent{
+		ProviderID:  new(big.Int).SetBytes(log.Topics[1].Bytes()),
+		Name:        event.Name,
+		Description: event.Description,
+	}, nil
+}
+
+// ParseProviderRemovedEvent decodes log as a ProviderRemoved event.
+func ParseProviderRemovedEvent(log types.Log) (*ProviderRemovedEvent, error) {
+	if len(log.Topics) != 2 {
+		return nil, fmt.Errorf("unexpected topic count for ProviderRemoved: got %d, want 2", len(log.Topics))
+	}
+	return &ProviderRemovedEvent{
+		ProviderID: new(big.Int).SetBytes(log.Topics[1].Bytes()),
+	}, nil
+}
+
+// ParsePayeeChangedEvent decodes log as a PayeeChanged event.
+func ParsePayeeChangedEvent(log types.Log) (*PayeeChangedEvent, error) {
+	if len(log.Topics) != 3 {
+		return nil, fmt.Errorf("unexpected topic count for PayeeChanged: got %d, want 3", len(log.Topics))
+	}
+
+	var event struct {
+		NewPayee common.Address
+	}
+	if err := registryEventsABI.UnpackIntoInterface(&event, "PayeeChanged", log.Data); err != nil {
+		return nil, fmt.Errorf("unpacking PayeeChanged data: %w", err)
+	}
+
+	return &PayeeChangedEvent{
+		ProviderID: new(big.Int).SetBytes(log.Topics[1].Bytes()),
+		OldPayee:   common.BytesToAddress(log.Topics[2].Bytes()),
+		NewPayee:   event.NewPayee,
+	}, nil
+}
+
+// ParseProviderApprovedEvent decodes log as a ProviderApproved event.
+func ParseProviderApprovedEvent(log types.Log) (*ProviderApprovedEvent, error) {
+	if len(log.Topics) != 2 {
+		return nil, fmt.Errorf("unexpected topic count for ProviderApproved: got %d, want 2", len(log.Topics))
+	}
+	return &ProviderApprovedEvent{
+		ProviderID: new(big.Int).SetBytes(log.Topics[1].Bytes()),
+	}, nil
+}
+
+// ParseProviderApprovalRevokedEvent decodes log as a
+// ProviderApprovalRevoked event.
+func ParseProviderApprovalRevokedEvent(log types.Log) (*ProviderApprovalRevokedEvent, error) {
+	if len(log.Topics) != 2 {
+		return nil, fmt.Errorf("unexpected topic count for ProviderApprovalRevoked: got %d, want 2", len(log.Topics))
+	}
+	return &ProviderApprovalRevokedEvent{
+		ProviderID: new(big.Int).SetBytes(log.Topics[1].Bytes()),
+	}, nil
+}
@@ -0,0 +1,92 @@
+package contract
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// remoteTxSigner delegates transaction signing to an external JSON-RPC
+// signer such as Clef or Frame, calling eth_signTransaction instead of
+// holding a private key in this process.
+type remoteTxSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// NewRemoteTxSigner dials a Clef-style JSON-RPC endpoint and returns a
+// TxSigner that signs on behalf of address via eth_signTransaction. If token
+// is non-empty, it's sent as a Bearer token on every request - see
+// dialExternalSigner.
+func NewRemoteTxSigner(ctx context.Context, url string, address common.Address, token string) (TxSigner, error) {
+	client, err := dialExternalSigner(ctx, url, token)
+	if err != nil {
+		return nil, fmt.Errorf("dialing external signer at %s: %w", url, err)
+	}
+	return &remoteTxSigner{client: client, address: address}, nil
+}
+
+func (s *remoteTxSigner) Address() common.Address {
+	return s.address
+}
+
+// txArgs mirrors the subset of go-ethereum's internal transactionArgs that
+// Clef and Frame expect from an eth_signTransaction request. GasPrice is
+// only set for a legacy transaction; a EIP-1559 (DynamicFeeTx) transaction
+// instead sets Type, MaxFeePerGas, and MaxPriorityFeePerGas, the same way
+// geth's own RPCTransaction marshaling branches on tx.Type().
+type txArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value,omitempty"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data,omitempty"`
+	ChainID              *hexutil.Big    `json:"chainId,omitempty"`
+	Type                 *hexutil.Uint64 `json:"type,omitempty"`
+}
+
+func (s *remoteTxSigner) SignTx(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	args := txArgs{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    hexutil.Bytes(tx.Data()),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+
+	if tx.Type() == types.DynamicFeeTxType {
+		txType := hexutil.Uint64(types.DynamicFeeTxType)
+		args.Type = &txType
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	var result struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := s.client.CallContext(ctx, &result, "eth_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("calling eth_signTransaction on external signer: %w", err)
+	}
+
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(result.Raw); err != nil {
+		return nil, fmt.Errorf("decoding signed transaction from external signer: %w", err)
+	}
+	if signed.Type() != tx.Type() {
+		return nil, fmt.Errorf("external signer returned transaction type %d, expected %d", signed.Type(), tx.Type())
+	}
+	return signed, nil
+}
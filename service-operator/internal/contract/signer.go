@@ -1,9 +1,12 @@
 package contract
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/storacha/filecoin-services/service-operator/internal/config"
 )
 
@@ -67,4 +70,189 @@ func (sm *SignerManager) LoadOwnerSigner() (*ecdsa.PrivateKey, error) {
 // LoadPayerSigner loads the private key for the payer role
 func (sm *SignerManager) LoadPayerSigner() (*ecdsa.PrivateKey, error) {
 	return sm.LoadSigner("payer")
+}
+
+// LoadSignerAtIndex loads the private key for role at the given HD account
+// index. If the role's signer is configured with a mnemonic or seed_file, the
+// key is derived at {derivation_path}/{accountIndex}; otherwise accountIndex
+// must be 0 and this behaves exactly like LoadSigner, since a single private
+// key or keystore file has no notion of an account index.
+//
+// Derived keys are cached per role+index so a single SignerManager can serve
+// many accounts from one seed without re-deriving on every call.
+func (sm *SignerManager) LoadSignerAtIndex(role string, accountIndex uint32) (*ecdsa.PrivateKey, error) {
+	signerConfig, exists := sm.config.Signers[role]
+	if !exists {
+		return nil, fmt.Errorf("signer for role '%s' not configured", role)
+	}
+
+	if signerConfig.Mnemonic == "" && signerConfig.SeedFile == "" {
+		if accountIndex != 0 {
+			return nil, fmt.Errorf("signer '%s': --account-index requires mnemonic or seed_file to be configured", role)
+		}
+		return sm.LoadSigner(role)
+	}
+
+	cacheKey := fmt.Sprintf("%s#%d", role, accountIndex)
+	if key, exists := sm.signers[cacheKey]; exists {
+		return key, nil
+	}
+
+	privateKey, err := DeriveHDKey(signerConfig, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("deriving HD key for role '%s' at index %d: %w", role, accountIndex, err)
+	}
+
+	sm.signers[cacheKey] = privateKey
+	return privateKey, nil
+}
+
+// LoadPayerSignerAtIndex loads the payer private key at the given HD account
+// index, enabling a single mnemonic/seed to back many payer accounts.
+func (sm *SignerManager) LoadPayerSignerAtIndex(accountIndex uint32) (*ecdsa.PrivateKey, error) {
+	return sm.LoadSignerAtIndex("payer", accountIndex)
+}
+
+// LoadTypedDataSigner returns a TypedDataSigner for the given role, backed by
+// whichever backend the role's SignerConfig selects (keystore, external,
+// ledger, or vault). Unlike LoadSigner, this does not require the key to ever
+// be loaded into process memory when the backend is external, ledger, or
+// vault.
+func (sm *SignerManager) LoadTypedDataSigner(ctx context.Context, role string) (TypedDataSigner, error) {
+	signerConfig, exists := sm.config.Signers[role]
+	if !exists {
+		return nil, fmt.Errorf("signer for role '%s' not configured", role)
+	}
+
+	backend := signerConfig.ResolvedBackend()
+
+	switch backend {
+	case config.SignerBackendKeystore:
+		privateKey, err := sm.LoadSigner(role)
+		if err != nil {
+			return nil, err
+		}
+		return NewKeystoreSigner(privateKey), nil
+
+	case config.SignerBackendExternal:
+		if !common.IsHexAddress(signerConfig.ExternalAddress) {
+			return nil, fmt.Errorf("signer '%s': invalid external_address: %s", role, signerConfig.ExternalAddress)
+		}
+		return NewExternalSigner(ctx, signerConfig.ExternalURL, common.HexToAddress(signerConfig.ExternalAddress), signerConfig.ExternalToken)
+
+	case config.SignerBackendLedger:
+		if LedgerSignerFactory == nil {
+			return nil, fmt.Errorf("signer '%s': ledger backend requires building with -tags ledger", role)
+		}
+		return LedgerSignerFactory(signerConfig.LedgerDerivationPath)
+
+	case config.SignerBackendVault:
+		return NewVaultSigner(ctx, signerConfig.VaultAddr, signerConfig.VaultToken, signerConfig.VaultTransitMount, signerConfig.VaultKeyName)
+
+	default:
+		return nil, fmt.Errorf("signer '%s': unknown backend %q", role, signerConfig.Backend)
+	}
+}
+
+// LoadOwnerTypedDataSigner returns the TypedDataSigner for the owner role.
+func (sm *SignerManager) LoadOwnerTypedDataSigner(ctx context.Context) (TypedDataSigner, error) {
+	return sm.LoadTypedDataSigner(ctx, "owner")
+}
+
+// LoadPayerTypedDataSigner returns the TypedDataSigner for the payer role.
+func (sm *SignerManager) LoadPayerTypedDataSigner(ctx context.Context) (TypedDataSigner, error) {
+	return sm.LoadTypedDataSigner(ctx, "payer")
+}
+
+// LoadTxSigner returns a TxSigner for the given role, backed by whichever
+// backend the role's SignerConfig selects (keystore, external, ledger,
+// aws-kms, gcp-kms, or vault). Unlike LoadSigner, this does not require the
+// key to ever be loaded into process memory when the backend is external,
+// ledger, a KMS, or vault, so settlement and other on-chain operations can be
+// driven by signers whose private key never leaves a hardware device, a
+// cloud HSM, or a Vault server.
+func (sm *SignerManager) LoadTxSigner(ctx context.Context, role string) (TxSigner, error) {
+	signerConfig, exists := sm.config.Signers[role]
+	if !exists {
+		return nil, fmt.Errorf("signer for role '%s' not configured", role)
+	}
+
+	backend := signerConfig.ResolvedBackend()
+
+	switch backend {
+	case config.SignerBackendKeystore:
+		privateKey, err := sm.LoadSigner(role)
+		if err != nil {
+			return nil, err
+		}
+		return NewKeystoreTxSigner(privateKey), nil
+
+	case config.SignerBackendExternal:
+		if !common.IsHexAddress(signerConfig.ExternalAddress) {
+			return nil, fmt.Errorf("signer '%s': invalid external_address: %s", role, signerConfig.ExternalAddress)
+		}
+		return NewRemoteTxSigner(ctx, signerConfig.ExternalURL, common.HexToAddress(signerConfig.ExternalAddress), signerConfig.ExternalToken)
+
+	case config.SignerBackendLedger:
+		if LedgerTxSignerFactory == nil {
+			return nil, fmt.Errorf("signer '%s': ledger backend requires building with -tags ledger", role)
+		}
+		return LedgerTxSignerFactory(signerConfig.LedgerDerivationPath)
+
+	case config.SignerBackendAWSKMS:
+		if AWSKMSSignerFactory == nil {
+			return nil, fmt.Errorf("signer '%s': aws-kms backend requires building with -tags kms", role)
+		}
+		return AWSKMSSignerFactory(ctx, signerConfig.KMSKeyID)
+
+	case config.SignerBackendGCPKMS:
+		if GCPKMSSignerFactory == nil {
+			return nil, fmt.Errorf("signer '%s': gcp-kms backend requires building with -tags kms", role)
+		}
+		return GCPKMSSignerFactory(ctx, signerConfig.KMSKeyID)
+
+	case config.SignerBackendVault:
+		return NewVaultTxSigner(ctx, signerConfig.VaultAddr, signerConfig.VaultToken, signerConfig.VaultTransitMount, signerConfig.VaultKeyName)
+
+	default:
+		return nil, fmt.Errorf("signer '%s': unknown backend %q", role, signerConfig.Backend)
+	}
+}
+
+// LoadOwnerTxSigner returns the TxSigner for the owner role.
+func (sm *SignerManager) LoadOwnerTxSigner(ctx context.Context) (TxSigner, error) {
+	return sm.LoadTxSigner(ctx, "owner")
+}
+
+// LoadPayerTxSigner returns the TxSigner for the payer role.
+func (sm *SignerManager) LoadPayerTxSigner(ctx context.Context) (TxSigner, error) {
+	return sm.LoadTxSigner(ctx, "payer")
+}
+
+// LoadPayerTxSignerAtIndex returns the payer TxSigner, deriving it at the
+// given HD account index when the payer's backend is "keystore" and a
+// mnemonic or seed_file is configured (see LoadSignerAtIndex). Other
+// backends have no notion of an account index of their own - a Ledger's
+// index is already fixed by its configured derivation path, and an
+// external/KMS signer signs for a single configured address - so
+// accountIndex must be 0 for those.
+func (sm *SignerManager) LoadPayerTxSignerAtIndex(ctx context.Context, accountIndex uint32) (TxSigner, error) {
+	signerConfig, exists := sm.config.Signers["payer"]
+	if !exists {
+		return nil, fmt.Errorf("signer for role 'payer' not configured")
+	}
+
+	backend := signerConfig.ResolvedBackend()
+	if backend != config.SignerBackendKeystore {
+		if accountIndex != 0 {
+			return nil, fmt.Errorf("signer 'payer': --account-index is not supported for backend %q", backend)
+		}
+		return sm.LoadTxSigner(ctx, "payer")
+	}
+
+	privateKey, err := sm.LoadSignerAtIndex("payer", accountIndex)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeystoreTxSigner(privateKey), nil
 }
\ No newline at end of file
@@ -0,0 +1,122 @@
+package contract
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// settleRailABI is the minimal ABI fragment for Payments.settleRail, parsed
+// once at init so a dry-run call can be encoded and its return values
+// decoded without a full generated contract binding.
+var settleRailABI abi.ABI
+
+func init() {
+	const settleRailJSON = `[{
+		"name": "settleRail",
+		"type": "function",
+		"stateMutability": "nonpayable",
+		"inputs": [
+			{"name": "railId", "type": "uint256"},
+			{"name": "untilEpoch", "type": "uint256"}
+		],
+		"outputs": [
+			{"name": "totalSettledAmount", "type": "uint256"},
+			{"name": "totalNetPayeeAmount", "type": "uint256"},
+			{"name": "totalOperatorCommission", "type": "uint256"},
+			{"name": "finalSettledEpoch", "type": "uint256"},
+			{"name": "note", "type": "string"}
+		]
+	}]`
+
+	parsed, err := abi.JSON(strings.NewReader(settleRailJSON))
+	if err != nil {
+		panic(fmt.Sprintf("parsing settleRail ABI: %v", err))
+	}
+	settleRailABI = parsed
+}
+
+// SimulatedSettlement is the projected outcome of settling a rail, computed
+// by dry-running Payments.settleRail via eth_call instead of broadcasting a
+// transaction.
+type SimulatedSettlement struct {
+	TotalSettledAmount      *big.Int
+	TotalNetPayeeAmount     *big.Int
+	TotalOperatorCommission *big.Int
+	FinalSettledEpoch       *big.Int
+	Note                    string
+	GasUsed                 uint64
+}
+
+// SimulateSettleRail dry-runs Payments.settleRail(railID, untilEpoch) as if
+// called by from, against the state named by blockTag ("pending", "latest",
+// or a decimal block number), and returns the same projected values a real
+// settlement would produce - without sending a transaction or paying the
+// network fee. A revert during simulation surfaces through the returned
+// error, same as it would from a real call.
+func SimulateSettleRail(
+	ctx context.Context,
+	rpcURL string,
+	paymentsAddress common.Address,
+	from common.Address,
+	railID, untilEpoch *big.Int,
+	blockTag string,
+) (*SimulatedSettlement, error) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to RPC: %w", err)
+	}
+	defer client.Close()
+
+	data, err := settleRailABI.Pack("settleRail", railID, untilEpoch)
+	if err != nil {
+		return nil, fmt.Errorf("encoding settleRail call: %w", err)
+	}
+
+	msg := ethereum.CallMsg{From: from, To: &paymentsAddress, Data: data}
+
+	// eth_estimateGas always runs against pending state; there is no variant
+	// that targets an arbitrary historical block, so GasUsed reflects
+	// pending state even when blockTag asks for something else.
+	gasUsed, err := client.EstimateGas(ctx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("estimating gas (settlement would revert): %w", err)
+	}
+
+	var result []byte
+	switch blockTag {
+	case "", "pending":
+		result, err = client.PendingCallContract(ctx, msg)
+	case "latest":
+		result, err = client.CallContract(ctx, msg, nil)
+	default:
+		blockNumber, ok := new(big.Int).SetString(blockTag, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid block tag %q: must be \"pending\", \"latest\", or a block number", blockTag)
+		}
+		result, err = client.CallContract(ctx, msg, blockNumber)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("simulating settleRail: %w", err)
+	}
+
+	outputs, err := settleRailABI.Unpack("settleRail", result)
+	if err != nil {
+		return nil, fmt.Errorf("decoding settleRail result: %w", err)
+	}
+
+	return &SimulatedSettlement{
+		TotalSettledAmount:      outputs[0].(*big.Int),
+		TotalNetPayeeAmount:     outputs[1].(*big.Int),
+		TotalOperatorCommission: outputs[2].(*big.Int),
+		FinalSettledEpoch:       outputs[3].(*big.Int),
+		Note:                    outputs[4].(string),
+		GasUsed:                 gasUsed,
+	}, nil
+}
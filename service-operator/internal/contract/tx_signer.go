@@ -0,0 +1,75 @@
+package contract
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TxSigner produces signed on-chain transactions for a single Ethereum
+// address, regardless of where the underlying private key actually lives (a
+// local keystore, an external Clef/Frame instance, a hardware wallet, or a
+// cloud KMS). It is the transaction-signing counterpart to TypedDataSigner.
+type TxSigner interface {
+	// SignTx signs tx for chainID and returns the signed transaction.
+	SignTx(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error)
+
+	// Address returns the Ethereum address this signer signs on behalf of.
+	Address() common.Address
+}
+
+// LedgerTxSignerFactory constructs a Ledger-backed TxSigner when set. It is
+// left nil in default builds and populated by the contract/ledgersigner
+// subpackage's init function when the binary is built with `-tags ledger`,
+// alongside LedgerSignerFactory.
+var LedgerTxSignerFactory func(derivationPath string) (TxSigner, error)
+
+// AWSKMSSignerFactory constructs an AWS KMS-backed TxSigner when set. It is
+// left nil in default builds and populated by the contract/kmssigner
+// subpackage's init function when built with `-tags kms`, keeping the AWS
+// SDK dependency out of ordinary builds.
+var AWSKMSSignerFactory func(ctx context.Context, keyID string) (TxSigner, error)
+
+// GCPKMSSignerFactory constructs a GCP Cloud KMS-backed TxSigner when set,
+// populated the same way as AWSKMSSignerFactory.
+var GCPKMSSignerFactory func(ctx context.Context, keyResourceName string) (TxSigner, error)
+
+// keystoreTxSigner signs transactions with a private key held in process
+// memory.
+type keystoreTxSigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewKeystoreTxSigner wraps a locally-loaded private key as a TxSigner.
+func NewKeystoreTxSigner(privateKey *ecdsa.PrivateKey) TxSigner {
+	return &keystoreTxSigner{privateKey: privateKey}
+}
+
+func (s *keystoreTxSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.privateKey.PublicKey)
+}
+
+func (s *keystoreTxSigner) SignTx(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	return types.SignTx(tx, signer, s.privateKey)
+}
+
+// CreateTransactorFromSigner builds a *bind.TransactOpts backed by signer
+// instead of a private key held in process memory, so Ledger/Trezor, remote
+// JSON-RPC, and KMS-backed signers can be used anywhere a *bind.TransactOpts
+// is expected, following the same bind.TransactOpts.Signer extension point
+// that bind.NewKeyedTransactorWithChainID uses internally.
+func CreateTransactorFromSigner(ctx context.Context, signer TxSigner, chainID *big.Int) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:    signer.Address(),
+		Context: ctx,
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return signer.SignTx(ctx, chainID, tx)
+		},
+	}
+}
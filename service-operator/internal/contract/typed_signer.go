@@ -0,0 +1,77 @@
+package contract
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/storacha/filecoin-services/go/eip712"
+)
+
+// TypedDataSigner produces EIP-712 signatures for a single Ethereum address,
+// regardless of where the underlying private key actually lives (a local
+// keystore, an external Clef/Frame instance, or a hardware wallet).
+type TypedDataSigner interface {
+	// SignTypedData signs typedData and returns it in the AuthSignature format
+	// consumed by eip712.ExtraDataEncoder.
+	SignTypedData(typedData apitypes.TypedData) (*eip712.AuthSignature, error)
+
+	// Address returns the Ethereum address this signer signs on behalf of.
+	Address() common.Address
+}
+
+// LedgerSignerFactory constructs a Ledger-backed TypedDataSigner when set.
+// It is left nil in default builds and populated by the contract/ledgersigner
+// subpackage's init function when the binary is built with `-tags ledger`,
+// keeping the go-ethereum/accounts/usbwallet (and its cgo/HID) dependency
+// out of ordinary builds.
+var LedgerSignerFactory func(derivationPath string) (TypedDataSigner, error)
+
+// keystoreSigner signs typed data with a private key held in process memory.
+type keystoreSigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewKeystoreSigner wraps a locally-loaded private key as a TypedDataSigner.
+func NewKeystoreSigner(privateKey *ecdsa.PrivateKey) TypedDataSigner {
+	return &keystoreSigner{privateKey: privateKey}
+}
+
+func (s *keystoreSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.privateKey.PublicKey)
+}
+
+func (s *keystoreSigner) SignTypedData(typedData apitypes.TypedData) (*eip712.AuthSignature, error) {
+	digest, err := eip712.Digest(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := crypto.Sign(digest[:], s.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("signing typed data: %w", err)
+	}
+
+	return authSignatureFromRSV(sig, digest[:], s.Address()), nil
+}
+
+// authSignatureFromRSV splits a 65-byte [R || S || V] signature (V in {0,1})
+// into the AuthSignature format, bumping V into the {27,28} range expected by
+// the contract's ECDSA recovery.
+func authSignatureFromRSV(sig []byte, digest []byte, signer common.Address) *eip712.AuthSignature {
+	v := sig[64]
+	if v < 27 {
+		v += 27
+	}
+	return &eip712.AuthSignature{
+		Signature:  sig,
+		V:          v,
+		R:          common.BytesToHash(sig[0:32]),
+		S:          common.BytesToHash(sig[32:64]),
+		SignedData: digest,
+		Signer:     signer,
+	}
+}
@@ -0,0 +1,392 @@
+// Package daemon implements a long-lived auto-settlement process for
+// service-operator: it watches the Payments contract for rail lifecycle
+// events, maintains an in-memory index of the configured service provider's
+// active rails, and settles them automatically once they accumulate enough
+// unsettled epochs or unsettled value.
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+)
+
+// Config configures a Daemon's settlement policy and endpoints.
+type Config struct {
+	RPCUrl          string
+	PaymentsAddress common.Address
+	TokenAddress    common.Address
+	ServiceProvider common.Address
+	TxSigner        contract.TxSigner
+	ChainID         *big.Int
+
+	// Interval is how often the daemon checks its rail index and settles
+	// any rail that has crossed MinUnsettledEpochs or MinAmount.
+	Interval time.Duration
+	// MinUnsettledEpochs is the minimum number of epochs since a rail's
+	// last settlement before the daemon will settle it again.
+	MinUnsettledEpochs *big.Int
+	// MinAmount is the minimum projected settlement amount (in the
+	// Payments contract's token units) before the daemon will settle a
+	// rail early, even if MinUnsettledEpochs hasn't been reached.
+	MinAmount *big.Int
+
+	// MetricsAddr, if non-empty, serves Prometheus metrics on this
+	// address at /metrics.
+	MetricsAddr string
+
+	Logger *slog.Logger
+}
+
+// railState tracks what the daemon currently knows about one active rail.
+type railState struct {
+	Payer              common.Address
+	Payee              common.Address
+	SettledUpToEpoch   *big.Int
+	LastSettledAmount  *big.Int
+	LastCommission     *big.Int
+	LastSettlementTime time.Time
+	Terminated         bool
+}
+
+// Daemon watches the Payments contract and settles this service provider's
+// rails automatically.
+type Daemon struct {
+	cfg Config
+	log *slog.Logger
+
+	mu    sync.Mutex
+	rails map[string]*railState
+
+	metrics *metrics
+}
+
+// New constructs a Daemon from cfg. cfg.Logger defaults to slog.Default()
+// when nil.
+func New(cfg Config) *Daemon {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Daemon{
+		cfg:     cfg,
+		log:     logger,
+		rails:   make(map[string]*railState),
+		metrics: newMetrics(),
+	}
+}
+
+// Run connects to the RPC endpoint, seeds the rail index with every rail
+// already paid to cfg.ServiceProvider, subscribes to rail lifecycle events,
+// and settles rails on cfg.Interval until ctx is cancelled. RPC failures
+// during the ongoing subscription and settlement sweeps are retried with
+// exponential backoff rather than returning an error, so a transient
+// outage doesn't take the daemon down; a failure to seed the rail index at
+// startup only logs a warning for the same reason, but does mean a rail
+// that became active before this call and isn't later touched by a
+// RailSettled/RailTerminated event stays untracked until the process is
+// restarted and seeding succeeds.
+func (d *Daemon) Run(ctx context.Context) error {
+	if d.cfg.MetricsAddr != "" {
+		go d.serveMetrics(ctx)
+	}
+
+	if err := d.seedRailIndex(ctx); err != nil {
+		d.log.Warn("seeding rail index, rails active before this startup may go untracked until restart", "error", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		d.watchEvents(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+		d.settlementLoop(ctx)
+	}()
+
+	wg.Wait()
+	return ctx.Err()
+}
+
+// seedRailIndex queries every rail already paid to cfg.ServiceProvider and
+// adds each to the rail index, the same way subscribeOnce's RailCreated
+// handling would once it starts - so a rail that became active before Run
+// was called (including across a daemon restart) is settled automatically
+// instead of silently sitting untracked until a human notices.
+func (d *Daemon) seedRailIndex(ctx context.Context) error {
+	summaries, err := contract.QueryRailsForPayee(ctx, d.cfg.RPCUrl, d.cfg.PaymentsAddress, d.cfg.ServiceProvider, d.cfg.TokenAddress)
+	if err != nil {
+		return fmt.Errorf("querying existing rails for payee %s: %w", d.cfg.ServiceProvider.Hex(), err)
+	}
+
+	seeded := 0
+	for _, summary := range summaries {
+		railInfo, err := contract.QueryRailInfo(ctx, d.cfg.RPCUrl, d.cfg.PaymentsAddress, summary.RailId)
+		if err != nil {
+			d.log.Warn("seeding rail index: querying rail info", "rail_id", summary.RailId.String(), "error", err)
+			continue
+		}
+
+		d.mu.Lock()
+		d.rails[railInfo.RailID.String()] = &railState{
+			Payer:            railInfo.From,
+			Payee:            railInfo.To,
+			SettledUpToEpoch: railInfo.SettledUpTo,
+			Terminated:       railInfo.IsTerminated,
+		}
+		d.mu.Unlock()
+		seeded++
+	}
+
+	d.log.Info("seeded rail index", "rail_count", seeded)
+	return nil
+}
+
+// watchEvents subscribes to RailCreated/RailSettled/RailTerminated logs and
+// applies them to the rail index, reconnecting with exponential backoff
+// whenever the subscription or the underlying connection drops.
+func (d *Daemon) watchEvents(ctx context.Context) {
+	backoff := newBackoff()
+
+	for ctx.Err() == nil {
+		if err := d.subscribeOnce(ctx); err != nil {
+			delay := backoff.next()
+			d.log.Error("event subscription failed, retrying", "error", err, "retry_in", delay)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			continue
+		}
+		backoff.reset()
+	}
+}
+
+func (d *Daemon) subscribeOnce(ctx context.Context) error {
+	client, err := ethclient.DialContext(ctx, d.cfg.RPCUrl)
+	if err != nil {
+		return fmt.Errorf("connecting to RPC: %w", err)
+	}
+	defer client.Close()
+
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{d.cfg.PaymentsAddress},
+		Topics:    [][]common.Hash{contract.RailEventTopics()},
+	}
+
+	logs := make(chan types.Log, 64)
+	sub, err := client.SubscribeFilterLogs(ctx, query, logs)
+	if err != nil {
+		return fmt.Errorf("subscribing to rail events: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	d.log.Info("subscribed to rail events", "payments_contract", d.cfg.PaymentsAddress.Hex())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			return fmt.Errorf("event subscription dropped: %w", err)
+		case vLog := <-logs:
+			d.handleLog(vLog)
+		}
+	}
+}
+
+func (d *Daemon) handleLog(vLog types.Log) {
+	if len(vLog.Topics) == 0 {
+		return
+	}
+
+	switch vLog.Topics[0] {
+	case contract.RailEventTopics()[0]:
+		event, err := contract.ParseRailCreatedEvent(vLog)
+		if err != nil {
+			d.log.Error("decoding RailCreated event", "error", err)
+			return
+		}
+		if event.Payee != d.cfg.ServiceProvider {
+			return
+		}
+		d.mu.Lock()
+		d.rails[event.RailID.String()] = &railState{
+			Payer:            event.Payer,
+			Payee:            event.Payee,
+			SettledUpToEpoch: big.NewInt(0),
+		}
+		d.mu.Unlock()
+		d.log.Info("rail created", "rail_id", event.RailID.String(), "payer", event.Payer.Hex())
+
+	case contract.RailEventTopics()[1]:
+		event, err := contract.ParseRailSettledEvent(vLog)
+		if err != nil {
+			d.log.Error("decoding RailSettled event", "error", err)
+			return
+		}
+		d.mu.Lock()
+		if rail, ok := d.rails[event.RailID.String()]; ok {
+			rail.SettledUpToEpoch = event.SettledUpToEpoch
+			rail.LastSettledAmount = event.TotalSettledAmount
+			rail.LastCommission = event.TotalOperatorCommission
+			rail.LastSettlementTime = time.Now()
+		}
+		d.mu.Unlock()
+		d.metrics.observeSettlement(event.RailID.String(), event.TotalSettledAmount, event.TotalOperatorCommission)
+		d.log.Info("rail settled", "rail_id", event.RailID.String(), "settled_up_to_epoch", event.SettledUpToEpoch.String())
+
+	case contract.RailEventTopics()[2]:
+		event, err := contract.ParseRailTerminatedEvent(vLog)
+		if err != nil {
+			d.log.Error("decoding RailTerminated event", "error", err)
+			return
+		}
+		d.mu.Lock()
+		if rail, ok := d.rails[event.RailID.String()]; ok {
+			rail.Terminated = true
+		}
+		d.mu.Unlock()
+		d.log.Info("rail terminated", "rail_id", event.RailID.String(), "end_epoch", event.EndEpoch.String())
+	}
+}
+
+// settlementLoop wakes up every cfg.Interval and settles any rail that has
+// crossed MinUnsettledEpochs or MinAmount since its last settlement.
+func (d *Daemon) settlementLoop(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.settleDueRails(ctx)
+		}
+	}
+}
+
+func (d *Daemon) settleDueRails(ctx context.Context) {
+	client, err := ethclient.DialContext(ctx, d.cfg.RPCUrl)
+	if err != nil {
+		d.log.Error("connecting to RPC for settlement sweep", "error", err)
+		return
+	}
+	defer client.Close()
+
+	currentEpoch, err := client.BlockNumber(ctx)
+	if err != nil {
+		d.log.Error("getting current block number", "error", err)
+		return
+	}
+	untilEpoch := new(big.Int).SetUint64(currentEpoch)
+
+	for _, railID := range d.candidateRailIDs(untilEpoch) {
+		if d.shouldSettle(ctx, railID, untilEpoch) {
+			d.settleRail(ctx, railID, untilEpoch)
+		}
+	}
+}
+
+// candidateRailIDs returns every non-terminated rail that has crossed
+// MinUnsettledEpochs, without holding the lock while the (slow) settlement
+// and simulation calls happen. Rails that haven't crossed the epoch
+// threshold are still worth a closer look via shouldSettle when MinAmount
+// is configured, so this intentionally casts a wide net.
+func (d *Daemon) candidateRailIDs(_ *big.Int) []*big.Int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var candidates []*big.Int
+	for id, rail := range d.rails {
+		if rail.Terminated {
+			continue
+		}
+		railID, ok := new(big.Int).SetString(id, 10)
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, railID)
+	}
+	return candidates
+}
+
+// shouldSettle decides whether railID is due for settlement: either it has
+// crossed MinUnsettledEpochs since its last settlement, or (when MinAmount
+// is configured) a cheap eth_call simulation shows it would already clear
+// MinAmount.
+func (d *Daemon) shouldSettle(ctx context.Context, railID, untilEpoch *big.Int) bool {
+	d.mu.Lock()
+	rail, ok := d.rails[railID.String()]
+	d.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	unsettledEpochs := new(big.Int).Sub(untilEpoch, rail.SettledUpToEpoch)
+	if d.cfg.MinUnsettledEpochs != nil && unsettledEpochs.Cmp(d.cfg.MinUnsettledEpochs) >= 0 {
+		return true
+	}
+	if d.cfg.MinAmount == nil {
+		return false
+	}
+
+	simulated, err := contract.SimulateSettleRail(ctx, d.cfg.RPCUrl, d.cfg.PaymentsAddress, d.cfg.ServiceProvider, railID, untilEpoch, "pending")
+	if err != nil {
+		d.log.Warn("simulating settlement to check --min-amount", "rail_id", railID.String(), "error", err)
+		return false
+	}
+	return simulated.TotalSettledAmount.Cmp(d.cfg.MinAmount) >= 0
+}
+
+func (d *Daemon) settleRail(ctx context.Context, railID, untilEpoch *big.Int) {
+	auth := contract.CreateTransactorFromSigner(ctx, d.cfg.TxSigner, d.cfg.ChainID)
+
+	result, err := contract.SettleRail(ctx, d.cfg.RPCUrl, d.cfg.PaymentsAddress, auth, railID, untilEpoch)
+	if err != nil {
+		d.log.Error("auto-settlement failed", "rail_id", railID.String(), "error", err)
+		return
+	}
+
+	d.log.Info("auto-settled rail",
+		"rail_id", railID.String(),
+		"transaction_hash", result.TransactionHash.Hex(),
+		"settled_up_to_epoch", result.FinalSettledEpoch.String(),
+		"total_settled_amount", result.TotalSettledAmount.String(),
+	)
+}
+
+func (d *Daemon) serveMetrics(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", d.metrics.handler())
+
+	server := &http.Server{Addr: d.cfg.MetricsAddr, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	d.log.Info("serving metrics", "addr", d.cfg.MetricsAddr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		d.log.Error("metrics server failed", "error", err)
+	}
+}
@@ -0,0 +1,80 @@
+// Package network bundles the canonical, well-known deployment details for
+// each Filecoin network service-operator talks to, so commands like `init`
+// don't require the operator to already know every contract address by
+// heart.
+package network
+
+import "fmt"
+
+// Registry describes one network's canonical FilecoinWarmStorageService
+// deployment. A field left empty means this binary genuinely doesn't know
+// that address yet - see MissingAddresses - rather than an invitation to
+// guess one.
+type Registry struct {
+	Name                           string
+	ChainID                        uint64
+	DefaultRPCUrl                  string
+	ServiceContractAddress         string
+	VerifierContractAddress        string
+	ServiceRegistryContractAddress string
+	PaymentsContractAddress        string
+	TokenContractAddress           string
+	// FaucetURL is where test USDFC can be requested on this network, or
+	// empty if the network has no faucet (e.g. mainnet).
+	FaucetURL string
+}
+
+// known holds the networks this binary ships addresses for. Entries here
+// come from values already published elsewhere in this repo's own docs and
+// examples; fields with no confirmed deployment address are left empty on
+// purpose.
+var known = map[string]Registry{
+	"calibration": {
+		Name:                    "calibration",
+		ChainID:                 314159,
+		DefaultRPCUrl:           "https://api.calibration.node.glif.io/rpc/v1",
+		ServiceContractAddress:  "0x8b7aa0a68f5717e400F1C4D37F7a28f84f76dF91",
+		PaymentsContractAddress: "0x6dB198201F900c17e86D267d7Df82567FB03df5E",
+		TokenContractAddress:    "0xb3042734b608a1B16e9e86B374A3f3e389B4cDf0",
+		FaucetURL:               "https://faucet.calibnet.chainsafe-fil.io/funds.html",
+	},
+	"mainnet": {
+		Name:          "mainnet",
+		ChainID:       314,
+		DefaultRPCUrl: "https://api.node.glif.io/rpc/v1",
+	},
+}
+
+// Lookup returns the bundled Registry for a network name ("calibration" or
+// "mainnet").
+func Lookup(name string) (Registry, error) {
+	reg, ok := known[name]
+	if !ok {
+		return Registry{}, fmt.Errorf("unknown network %q (supported: calibration, mainnet)", name)
+	}
+	return reg, nil
+}
+
+// MissingAddresses reports which canonical contract addresses aren't
+// bundled for r yet, in the order init prints its flags, so a caller can
+// tell the operator exactly which --*-address flags they still need to
+// supply by hand.
+func (r Registry) MissingAddresses() []string {
+	var missing []string
+	if r.ServiceContractAddress == "" {
+		missing = append(missing, "service-contract-address")
+	}
+	if r.VerifierContractAddress == "" {
+		missing = append(missing, "verifier-contract-address")
+	}
+	if r.ServiceRegistryContractAddress == "" {
+		missing = append(missing, "service-registry-contract-address")
+	}
+	if r.PaymentsContractAddress == "" {
+		missing = append(missing, "payments-contract-address")
+	}
+	if r.TokenContractAddress == "" {
+		missing = append(missing, "token-contract-address")
+	}
+	return missing
+}
@@ -0,0 +1,63 @@
+// Package output implements the global --output=text|json|yaml flag shared
+// by every service-operator command: commands collect their results into a
+// plain struct and call Emit, which renders it as JSON or YAML when the user
+// asked for machine-readable output, or reports that the caller should fall
+// back to its own human-readable printing.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the result rendering mode selected via the global --output flag.
+type Format string
+
+const (
+	Text Format = "text"
+	JSON Format = "json"
+	YAML Format = "yaml"
+)
+
+// Selected returns the Format chosen via --output, defaulting to Text.
+func Selected() Format {
+	switch viper.GetString("output") {
+	case string(JSON):
+		return JSON
+	case string(YAML):
+		return YAML
+	default:
+		return Text
+	}
+}
+
+// Emit renders result as indented JSON or YAML to stdout when the selected
+// format is JSON or YAML, respectively, and reports true. When the selected
+// format is Text it does nothing and reports false, so the caller should
+// proceed with its own printing.
+func Emit(result any) (bool, error) {
+	switch Selected() {
+	case JSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return true, fmt.Errorf("encoding JSON output: %w", err)
+		}
+		return true, nil
+
+	case YAML:
+		enc := yaml.NewEncoder(os.Stdout)
+		defer enc.Close()
+		if err := enc.Encode(result); err != nil {
+			return true, fmt.Errorf("encoding YAML output: %w", err)
+		}
+		return true, nil
+
+	default:
+		return false, nil
+	}
+}
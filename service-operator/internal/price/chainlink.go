@@ -0,0 +1,141 @@
+package price
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// aggregatorV3ABI is the minimal ABI fragment for Chainlink's
+// AggregatorV3Interface, parsed once at init so a feed can be queried
+// without vendoring the full Chainlink contracts package.
+var aggregatorV3ABI abi.ABI
+
+func init() {
+	const aggregatorV3JSON = `[
+		{
+			"name": "latestRoundData",
+			"type": "function",
+			"stateMutability": "view",
+			"inputs": [],
+			"outputs": [
+				{"name": "roundId", "type": "uint80"},
+				{"name": "answer", "type": "int256"},
+				{"name": "startedAt", "type": "uint256"},
+				{"name": "updatedAt", "type": "uint256"},
+				{"name": "answeredInRound", "type": "uint80"}
+			]
+		},
+		{
+			"name": "decimals",
+			"type": "function",
+			"stateMutability": "view",
+			"inputs": [],
+			"outputs": [{"name": "", "type": "uint8"}]
+		}
+	]`
+
+	parsed, err := abi.JSON(strings.NewReader(aggregatorV3JSON))
+	if err != nil {
+		panic(fmt.Sprintf("parsing AggregatorV3Interface ABI: %v", err))
+	}
+	aggregatorV3ABI = parsed
+}
+
+// ChainlinkSource reads a USD-per-token price from a Chainlink
+// AggregatorV3Interface feed, rejecting rounds older than MaxAge.
+type ChainlinkSource struct {
+	RPCUrl      string
+	FeedAddress common.Address
+	MaxAge      time.Duration
+}
+
+// NewChainlinkSource builds a ChainlinkSource querying feedAddress over
+// rpcURL, rejecting any round last updated more than maxAge ago.
+func NewChainlinkSource(rpcURL string, feedAddress common.Address, maxAge time.Duration) *ChainlinkSource {
+	return &ChainlinkSource{RPCUrl: rpcURL, FeedAddress: feedAddress, MaxAge: maxAge}
+}
+
+// FetchPrice calls decimals() and latestRoundData() on the configured feed
+// and converts the raw integer answer to a USD-per-token big.Float.
+func (s *ChainlinkSource) FetchPrice(ctx context.Context) (*Price, error) {
+	client, err := ethclient.DialContext(ctx, s.RPCUrl)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to RPC: %w", err)
+	}
+	defer client.Close()
+
+	feedDecimals, err := s.callDecimals(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	roundID, answer, updatedAt, err := s.callLatestRoundData(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if answer.Sign() <= 0 {
+		return nil, fmt.Errorf("chainlink feed %s returned a non-positive answer: %s", s.FeedAddress.Hex(), answer.String())
+	}
+
+	observedAt := time.Unix(updatedAt.Int64(), 0)
+	if s.MaxAge > 0 {
+		if age := time.Since(observedAt); age > s.MaxAge {
+			return nil, fmt.Errorf("chainlink feed %s price is stale: last updated %s ago (max age %s)", s.FeedAddress.Hex(), age.Round(time.Second), s.MaxAge)
+		}
+	}
+
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(feedDecimals)), nil))
+	usdPerToken := new(big.Float).Quo(new(big.Float).SetInt(answer), scale)
+
+	return &Price{
+		USDPerToken: usdPerToken,
+		Source:      "chainlink",
+		RoundID:     roundID.String(),
+		ObservedAt:  observedAt,
+	}, nil
+}
+
+func (s *ChainlinkSource) callDecimals(ctx context.Context, client *ethclient.Client) (uint8, error) {
+	data, err := aggregatorV3ABI.Pack("decimals")
+	if err != nil {
+		return 0, fmt.Errorf("encoding decimals() call: %w", err)
+	}
+
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &s.FeedAddress, Data: data}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("calling decimals() on chainlink feed %s: %w", s.FeedAddress.Hex(), err)
+	}
+
+	outputs, err := aggregatorV3ABI.Unpack("decimals", result)
+	if err != nil {
+		return 0, fmt.Errorf("decoding decimals() result: %w", err)
+	}
+	return outputs[0].(uint8), nil
+}
+
+func (s *ChainlinkSource) callLatestRoundData(ctx context.Context, client *ethclient.Client) (roundID, answer, updatedAt *big.Int, err error) {
+	data, err := aggregatorV3ABI.Pack("latestRoundData")
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("encoding latestRoundData() call: %w", err)
+	}
+
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &s.FeedAddress, Data: data}, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("calling latestRoundData() on chainlink feed %s: %w", s.FeedAddress.Hex(), err)
+	}
+
+	outputs, err := aggregatorV3ABI.Unpack("latestRoundData", result)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("decoding latestRoundData() result: %w", err)
+	}
+
+	return outputs[0].(*big.Int), outputs[1].(*big.Int), outputs[3].(*big.Int), nil
+}
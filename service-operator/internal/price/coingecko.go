@@ -0,0 +1,73 @@
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const coinGeckoSimplePriceURL = "https://api.coingecko.com/api/v3/simple/price"
+
+// CoinGeckoSource reads a USD-per-token price from CoinGecko's public
+// "simple price" endpoint. CoinGecko doesn't expose a round/update
+// identifier, so Price.RoundID is always empty and Price.ObservedAt is the
+// time of the HTTP request rather than an upstream timestamp.
+type CoinGeckoSource struct {
+	CoinID     string
+	HTTPClient *http.Client
+}
+
+// NewCoinGeckoSource builds a CoinGeckoSource for the given CoinGecko coin
+// ID (e.g. "usd-coin", not the token's ticker symbol).
+func NewCoinGeckoSource(coinID string) *CoinGeckoSource {
+	return &CoinGeckoSource{
+		CoinID:     coinID,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// FetchPrice queries CoinGecko's simple/price endpoint for CoinID's current
+// USD value.
+func (s *CoinGeckoSource) FetchPrice(ctx context.Context) (*Price, error) {
+	if s.CoinID == "" {
+		return nil, fmt.Errorf("--coingecko-id is required when --price-source=coingecko")
+	}
+
+	reqURL := fmt.Sprintf("%s?ids=%s&vs_currencies=usd", coinGeckoSimplePriceURL, url.QueryEscape(s.CoinID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building coingecko request: %w", err)
+	}
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("querying coingecko: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("coingecko returned status %s", resp.Status)
+	}
+
+	var body map[string]struct {
+		USD float64 `json:"usd"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding coingecko response: %w", err)
+	}
+
+	entry, ok := body[s.CoinID]
+	if !ok || entry.USD <= 0 {
+		return nil, fmt.Errorf("coingecko has no usd price for coin id %q", s.CoinID)
+	}
+
+	return &Price{
+		USDPerToken: big.NewFloat(entry.USD),
+		Source:      "coingecko",
+		ObservedAt:  time.Now(),
+	}, nil
+}
@@ -0,0 +1,33 @@
+package price
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// FixedSource reports an operator-supplied literal USD-per-token price. It
+// never changes and carries no staleness or round information, so it's the
+// right choice for tokens the operator has deliberately pegged themselves
+// (or for testing).
+type FixedSource struct {
+	usdPerToken *big.Float
+}
+
+// NewFixedSource builds a FixedSource reporting usdPerToken for every call.
+func NewFixedSource(usdPerToken float64) (*FixedSource, error) {
+	if usdPerToken <= 0 {
+		return nil, fmt.Errorf("--price must be a positive USD-per-token value, got %v", usdPerToken)
+	}
+	return &FixedSource{usdPerToken: big.NewFloat(usdPerToken)}, nil
+}
+
+// FetchPrice returns the configured price, timestamped as of this call.
+func (s *FixedSource) FetchPrice(ctx context.Context) (*Price, error) {
+	return &Price{
+		USDPerToken: s.usdPerToken,
+		Source:      "fixed",
+		ObservedAt:  time.Now(),
+	}, nil
+}
@@ -0,0 +1,59 @@
+// Package price resolves a USD-per-token exchange rate from one of several
+// sources (a Chainlink price feed, CoinGecko, or an operator-supplied
+// literal) so that `payments convert` can support tokens whose value isn't
+// pegged 1:1 to the dollar.
+package price
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Price is a USD-per-token exchange rate as reported by a Source, along with
+// enough provenance that an operator can audit the number before depositing
+// against it.
+type Price struct {
+	// USDPerToken is how many dollars one whole token is worth.
+	USDPerToken *big.Float
+	// Source identifies which backend produced this price, e.g. "chainlink",
+	// "coingecko", or "fixed".
+	Source string
+	// RoundID is the upstream round/update identifier, when the source has
+	// one (Chainlink). Empty for sources that don't.
+	RoundID string
+	// ObservedAt is when the source says the price was last updated.
+	ObservedAt time.Time
+}
+
+// Source fetches the current USD-per-token price for a single configured
+// token.
+type Source interface {
+	FetchPrice(ctx context.Context) (*Price, error)
+}
+
+// ConvertDollarsToBaseUnits converts a dollar amount to base token units at
+// the given price, computed as:
+//
+//	baseUnits = dollars / USDPerToken * 10^tokenDecimals
+//
+// The whole computation is done in big.Float/big.Int so that large decimal
+// counts (or very small/large prices) don't lose precision the way a
+// float64 multiplication would.
+func ConvertDollarsToBaseUnits(dollars float64, usdPerToken *big.Float, tokenDecimals uint8) (*big.Int, error) {
+	if usdPerToken == nil || usdPerToken.Sign() <= 0 {
+		return nil, fmt.Errorf("price must be a positive USD-per-token value")
+	}
+	if dollars < 0 {
+		return nil, fmt.Errorf("dollar amount must not be negative")
+	}
+
+	tokens := new(big.Float).SetPrec(256).Quo(big.NewFloat(dollars), usdPerToken)
+
+	scale := new(big.Float).SetPrec(256).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(tokenDecimals)), nil))
+	baseUnitsFloat := new(big.Float).SetPrec(256).Mul(tokens, scale)
+
+	baseUnits, _ := baseUnitsFloat.Int(nil)
+	return baseUnits, nil
+}
@@ -0,0 +1,61 @@
+package refill
+
+import (
+	"math/big"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metrics holds the Prometheus series a Monitor exposes on /metrics.
+type metrics struct {
+	registry *prometheus.Registry
+
+	balance        prometheus.Gauge
+	refillTotal    prometheus.Counter
+	refillFailures prometheus.Counter
+}
+
+func newMetrics() *metrics {
+	registry := prometheus.NewRegistry()
+
+	m := &metrics{
+		registry: registry,
+		balance: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "payments_balance_base_units",
+			Help: "Free balance (funds minus current lockup) of the monitored payer account in the Payments contract, in the token's smallest unit.",
+		}),
+		refillTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "payments_refill_total",
+			Help: "Total number of successful auto-refill deposits.",
+		}),
+		refillFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "payments_refill_failures_total",
+			Help: "Total number of auto-refill deposits that were attempted and failed, or skipped because a guardrail (wallet balance, daily cap, absolute cap) blocked them.",
+		}),
+	}
+
+	registry.MustRegister(m.balance, m.refillTotal, m.refillFailures)
+	return m
+}
+
+func (m *metrics) observeBalance(balance *big.Int) {
+	m.balance.Set(bigIntToFloat(balance))
+}
+
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// bigIntToFloat converts a token amount to float64 for Prometheus, which has
+// no arbitrary-precision numeric type. This loses precision for very large
+// values, which is acceptable for a dashboard gauge but not for accounting.
+func bigIntToFloat(amount *big.Int) float64 {
+	if amount == nil {
+		return 0
+	}
+	f := new(big.Float).SetInt(amount)
+	value, _ := f.Float64()
+	return value
+}
@@ -0,0 +1,226 @@
+// Package refill implements a long-lived process for service-operator that
+// watches the Payments contract's free balance for a payer account and
+// deposits more USDFC automatically once it drops below a configured
+// threshold, modeled on the refill-gas daemons common to other chains'
+// operator tooling.
+package refill
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+)
+
+// Config configures a Monitor's polling interval and refill policy.
+type Config struct {
+	RPCUrl          string
+	PaymentsAddress common.Address
+	TokenAddress    common.Address
+	PayerAddress    common.Address
+	TxSigner        contract.TxSigner
+	ChainID         *big.Int
+
+	// Interval is how often the monitor checks the payer's free balance.
+	Interval time.Duration
+	// Threshold is the free balance below which a refill is triggered.
+	// Required.
+	Threshold *big.Int
+	// Target is the free balance a refill tops up to. Required, and must be
+	// greater than Threshold.
+	Target *big.Int
+	// DailyCap, if set, bounds how much the monitor will deposit within any
+	// rolling 24h window.
+	DailyCap *big.Int
+	// AbsoluteCap, if set, bounds how much the monitor will ever deposit
+	// over its entire run.
+	AbsoluteCap *big.Int
+
+	// AutoRefill gates whether the monitor actually deposits funds (true)
+	// or only observes and logs/exports the balance (false), so the same
+	// binary can run as a read-only alerting probe.
+	AutoRefill bool
+
+	// MetricsAddr, if non-empty, serves Prometheus metrics on this
+	// address at /metrics.
+	MetricsAddr string
+
+	Logger *slog.Logger
+}
+
+// Monitor watches a payer's free balance in the Payments contract and
+// deposits more when it drops below Config.Threshold.
+type Monitor struct {
+	cfg Config
+	log *slog.Logger
+
+	mu            sync.Mutex
+	dayStart      time.Time
+	refilledToday *big.Int
+	totalRefilled *big.Int
+
+	metrics *metrics
+}
+
+// New constructs a Monitor from cfg. cfg.Logger defaults to slog.Default()
+// when nil.
+func New(cfg Config) *Monitor {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Monitor{
+		cfg:           cfg,
+		log:           logger,
+		dayStart:      time.Now(),
+		refilledToday: big.NewInt(0),
+		totalRefilled: big.NewInt(0),
+		metrics:       newMetrics(),
+	}
+}
+
+// Run polls the payer's balance on cfg.Interval, refilling it whenever
+// cfg.AutoRefill is set and the balance has dropped below cfg.Threshold,
+// until ctx is cancelled.
+func (m *Monitor) Run(ctx context.Context) error {
+	if m.cfg.MetricsAddr != "" {
+		go m.serveMetrics(ctx)
+	}
+
+	m.check(ctx)
+
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			m.check(ctx)
+		}
+	}
+}
+
+// check queries the payer's current free balance, reports it as a metric,
+// and - when cfg.AutoRefill is set - deposits up to cfg.Target if it has
+// dropped below cfg.Threshold.
+func (m *Monitor) check(ctx context.Context) {
+	client, err := ethclient.DialContext(ctx, m.cfg.RPCUrl)
+	if err != nil {
+		m.log.Error("connecting to RPC", "error", err)
+		return
+	}
+	defer client.Close()
+
+	paymentsContract, err := bindings.NewPayments(m.cfg.PaymentsAddress, client)
+	if err != nil {
+		m.log.Error("creating payments contract binding", "error", err)
+		return
+	}
+
+	account, err := paymentsContract.Accounts(nil, m.cfg.TokenAddress, m.cfg.PayerAddress)
+	if err != nil {
+		m.log.Error("querying account balance", "error", err)
+		return
+	}
+	free := new(big.Int).Sub(account.Funds, account.LockupCurrent)
+	m.metrics.observeBalance(free)
+	m.log.Info("checked payer balance", "payer", m.cfg.PayerAddress.Hex(), "free_balance", free.String(), "threshold", m.cfg.Threshold.String())
+
+	if free.Cmp(m.cfg.Threshold) >= 0 {
+		return
+	}
+	if !m.cfg.AutoRefill {
+		m.log.Warn("free balance below threshold, but --auto-refill is not set", "free_balance", free.String(), "threshold", m.cfg.Threshold.String())
+		return
+	}
+
+	amount := new(big.Int).Sub(m.cfg.Target, free)
+	if amount.Sign() <= 0 {
+		return
+	}
+
+	if err := m.refill(ctx, client, paymentsContract, amount); err != nil {
+		m.log.Error("auto-refill failed", "amount", amount.String(), "error", err)
+		m.metrics.refillFailures.Inc()
+	}
+}
+
+// refill deposits amount into the payer's Payments account, subject to the
+// daily/absolute caps and a wallet-balance check, resetting the daily cap
+// counter once a day has elapsed since it was last reset.
+func (m *Monitor) refill(ctx context.Context, client *ethclient.Client, paymentsContract *bindings.Payments, amount *big.Int) error {
+	m.mu.Lock()
+	if time.Since(m.dayStart) >= 24*time.Hour {
+		m.dayStart = time.Now()
+		m.refilledToday = big.NewInt(0)
+	}
+	if m.cfg.DailyCap != nil && new(big.Int).Add(m.refilledToday, amount).Cmp(m.cfg.DailyCap) > 0 {
+		m.mu.Unlock()
+		return fmt.Errorf("refilling %s would exceed daily cap %s (already refilled %s today)", amount.String(), m.cfg.DailyCap.String(), m.refilledToday.String())
+	}
+	if m.cfg.AbsoluteCap != nil && new(big.Int).Add(m.totalRefilled, amount).Cmp(m.cfg.AbsoluteCap) > 0 {
+		m.mu.Unlock()
+		return fmt.Errorf("refilling %s would exceed absolute cap %s (already refilled %s total)", amount.String(), m.cfg.AbsoluteCap.String(), m.totalRefilled.String())
+	}
+	m.mu.Unlock()
+
+	walletBalance, err := contract.QueryTokenBalance(ctx, m.cfg.RPCUrl, m.cfg.TokenAddress, m.cfg.PayerAddress)
+	if err != nil {
+		return fmt.Errorf("querying wallet balance: %w", err)
+	}
+	if walletBalance.Cmp(amount) < 0 {
+		return fmt.Errorf("wallet balance %s is less than the %s needed to refill", walletBalance.String(), amount.String())
+	}
+
+	auth := contract.CreateTransactorFromSigner(ctx, m.cfg.TxSigner, m.cfg.ChainID)
+	tx, err := paymentsContract.Deposit(auth, m.cfg.TokenAddress, m.cfg.PayerAddress, amount)
+	if err != nil {
+		return fmt.Errorf("calling deposit: %w", err)
+	}
+
+	receipt, err := contract.WaitForTransaction(ctx, client, tx.Hash())
+	if err != nil {
+		return fmt.Errorf("waiting for deposit transaction: %w", err)
+	}
+
+	m.mu.Lock()
+	m.refilledToday.Add(m.refilledToday, amount)
+	m.totalRefilled.Add(m.totalRefilled, amount)
+	m.mu.Unlock()
+
+	m.metrics.refillTotal.Inc()
+	m.log.Info("auto-refilled payer balance",
+		"amount", amount.String(),
+		"transaction_hash", receipt.TxHash.Hex(),
+	)
+	return nil
+}
+
+func (m *Monitor) serveMetrics(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.metrics.handler())
+
+	server := &http.Server{Addr: m.cfg.MetricsAddr, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	m.log.Info("serving metrics", "addr", m.cfg.MetricsAddr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		m.log.Error("metrics server failed", "error", err)
+	}
+}
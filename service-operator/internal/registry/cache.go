@@ -0,0 +1,110 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Cache is an on-disk, TTL-based cache of ProviderView metadata keyed by
+// provider ID, so repeated `status`/`watch` polls don't re-fetch unchanged
+// provider name/payee pairs from the registry on every tick. It's safe for
+// concurrent use.
+type Cache struct {
+	path string
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	dirty   bool
+}
+
+type cacheEntry struct {
+	Name      string    `json:"name"`
+	Payee     string    `json:"payee"`
+	IsActive  bool      `json:"isActive"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// OpenCache loads the cache file at path, if it exists, and returns a Cache
+// that expires entries older than ttl. A missing file is treated as an empty
+// cache rather than an error, so the first run on a given machine doesn't
+// need to pre-create it.
+func OpenCache(path string, ttl time.Duration) (*Cache, error) {
+	c := &Cache{path: path, ttl: ttl, entries: make(map[string]cacheEntry)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading registry cache %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		return nil, fmt.Errorf("parsing registry cache %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Get returns the cached ProviderView for id, and false if it's absent or
+// older than the cache's TTL.
+func (c *Cache) Get(id *big.Int) (ProviderView, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[id.String()]
+	if !ok || time.Since(entry.FetchedAt) > c.ttl {
+		return ProviderView{}, false
+	}
+	return ProviderView{
+		ProviderID: id,
+		Name:       entry.Name,
+		Payee:      common.HexToAddress(entry.Payee),
+		IsActive:   entry.IsActive,
+	}, true
+}
+
+// Put records v as freshly fetched. Call Flush afterward to persist it.
+func (c *Cache) Put(v ProviderView) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[v.ProviderID.String()] = cacheEntry{
+		Name:      v.Name,
+		Payee:     v.Payee.Hex(),
+		IsActive:  v.IsActive,
+		FetchedAt: time.Now(),
+	}
+	c.dirty = true
+}
+
+// Flush writes the cache to disk if anything has changed since it was
+// opened or last flushed.
+func (c *Cache) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling registry cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("creating registry cache directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0o600); err != nil {
+		return fmt.Errorf("writing registry cache %s: %w", c.path, err)
+	}
+	c.dirty = false
+	return nil
+}
@@ -0,0 +1,166 @@
+// Package registry implements paginated, concurrent enumeration of the
+// ServiceProviderRegistry's active providers, for `payments status` and
+// `payments watch`, which both need every registered provider's payee
+// address and can no longer rely on a single-page query once the registry
+// grows past a page: a hardcoded GetAllActiveProviders(0, 1000) call
+// silently truncates at the 1000th provider.
+package registry
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/storacha/filecoin-services/go/bindings"
+)
+
+// DefaultPageSize is used when Config.PageSize is nil.
+var DefaultPageSize = big.NewInt(1000)
+
+// DefaultConcurrency is used when Config.Concurrency is zero.
+const DefaultConcurrency = 4
+
+// ProviderView is the subset of a registered provider's details that
+// EnumerateActiveProviders reports: enough to look up its rails and label
+// them, without callers needing the full registry binding type.
+type ProviderView struct {
+	ProviderID *big.Int
+	Name       string
+	Payee      common.Address
+	IsActive   bool
+}
+
+// Config controls EnumerateActiveProviders' pagination, concurrency, and
+// caching.
+type Config struct {
+	// PageSize is how many provider IDs to request per GetAllActiveProviders
+	// call. Defaults to DefaultPageSize.
+	PageSize *big.Int
+	// Concurrency bounds how many GetProvidersByIds batches are in flight at
+	// once. Defaults to DefaultConcurrency.
+	Concurrency int
+	// Cache, if non-nil, is consulted before fetching a provider's details
+	// and updated after a successful fetch, so repeated enumerations within
+	// Cache's TTL skip the GetProvidersByIds round-trip entirely.
+	Cache *Cache
+}
+
+// EnumerateActiveProviders pages through the registry's active providers via
+// GetAllActiveProviders, fetching each page's details via GetProvidersByIds
+// (skipping IDs served from cfg.Cache) with up to cfg.Concurrency batches in
+// flight, and streams every active provider found to the returned channel.
+// Per-provider or per-page errors are sent to the error channel rather than
+// aborting enumeration, so one bad page doesn't hide every other provider.
+// Both channels are closed once enumeration finishes or ctx is cancelled.
+func EnumerateActiveProviders(ctx context.Context, client *ethclient.Client, registryAddress common.Address, cfg Config) (<-chan ProviderView, <-chan error) {
+	views := make(chan ProviderView)
+	errs := make(chan error)
+
+	pageSize := cfg.PageSize
+	if pageSize == nil {
+		pageSize = DefaultPageSize
+	}
+	concurrency := cfg.Concurrency
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+
+	go func() {
+		defer close(views)
+		defer close(errs)
+
+		registryBinding, err := bindings.NewServiceProviderRegistry(registryAddress, client)
+		if err != nil {
+			errs <- fmt.Errorf("creating registry binding: %w", err)
+			return
+		}
+
+		sem := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+
+		offset := new(big.Int)
+	pageLoop:
+		for {
+			if ctx.Err() != nil {
+				break
+			}
+
+			page, err := registryBinding.GetAllActiveProviders(nil, offset, pageSize)
+			if err != nil {
+				errs <- fmt.Errorf("listing active providers at offset %s: %w", offset.String(), err)
+				break
+			}
+			if len(page.ProviderIds) == 0 {
+				break
+			}
+
+			var needFetch []*big.Int
+			for _, id := range page.ProviderIds {
+				if cfg.Cache != nil {
+					if cached, ok := cfg.Cache.Get(id); ok {
+						if cached.IsActive {
+							views <- cached
+						}
+						continue
+					}
+				}
+				needFetch = append(needFetch, id)
+			}
+
+			if len(needFetch) > 0 {
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					break pageLoop
+				}
+				wg.Add(1)
+				go func(ids []*big.Int) {
+					defer wg.Done()
+					defer func() { <-sem }()
+					fetchProviderBatch(registryBinding, ids, views, errs, cfg.Cache)
+				}(needFetch)
+			}
+
+			if len(page.ProviderIds) < int(pageSize.Int64()) {
+				break
+			}
+			offset = new(big.Int).Add(offset, pageSize)
+		}
+
+		wg.Wait()
+	}()
+
+	return views, errs
+}
+
+// fetchProviderBatch fetches one page's worth of provider details and emits
+// every active one to views, caching each as it's fetched.
+func fetchProviderBatch(registryBinding *bindings.ServiceProviderRegistry, ids []*big.Int, views chan<- ProviderView, errs chan<- error, cache *Cache) {
+	info, err := registryBinding.GetProvidersByIds(nil, ids)
+	if err != nil {
+		errs <- fmt.Errorf("getting details for %d provider(s): %w", len(ids), err)
+		return
+	}
+
+	for i, providerView := range info.ProviderInfos {
+		if !info.ValidIds[i] {
+			continue
+		}
+		v := ProviderView{
+			ProviderID: providerView.ProviderId,
+			Name:       providerView.Info.Name,
+			Payee:      providerView.Info.Payee,
+			IsActive:   providerView.Info.IsActive,
+		}
+		if cache != nil {
+			cache.Put(v)
+		}
+		if v.IsActive {
+			views <- v
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package watch
+
+import "time"
+
+const (
+	backoffInitial = 1 * time.Second
+	backoffMax     = 2 * time.Minute
+	backoffFactor  = 2
+)
+
+// backoff produces exponentially increasing delays, capped at backoffMax,
+// for retrying a flaky operation (here, a poll tick that failed to reach the
+// RPC endpoint).
+type backoff struct {
+	next_ time.Duration
+}
+
+func newBackoff() *backoff {
+	return &backoff{next_: backoffInitial}
+}
+
+// next returns the delay to wait before the next retry, then doubles it
+// (capped at backoffMax) for the retry after that.
+func (b *backoff) next() time.Duration {
+	delay := b.next_
+	b.next_ *= backoffFactor
+	if b.next_ > backoffMax {
+		b.next_ = backoffMax
+	}
+	return delay
+}
+
+// reset returns the backoff to its initial delay after a successful poll, so
+// a later failure doesn't inherit a long delay from a past outage.
+func (b *backoff) reset() {
+	b.next_ = backoffInitial
+}
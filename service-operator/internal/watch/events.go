@@ -0,0 +1,68 @@
+package watch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// EventType identifies what a Watcher decided about a rail on a given poll
+// tick.
+type EventType string
+
+const (
+	// EventSettled means the rail was settled on-chain.
+	EventSettled EventType = "settled"
+	// EventSkipped means settlement was attempted but failed, or was due
+	// but deliberately not attempted for a reason other than low funds or
+	// exhausted allowance.
+	EventSkipped EventType = "skipped"
+	// EventFundsLow means settlement was due but skipped because the
+	// payer's available funds were below --min-available-funds.
+	EventFundsLow EventType = "funds_low"
+	// EventAllowanceExhausted means settlement was due but skipped
+	// because the configured --max-gas-price (or another allowance-style
+	// guardrail) would be violated.
+	EventAllowanceExhausted EventType = "allowance_exhausted"
+)
+
+// Event is emitted once per settlement decision, to stdout and (if
+// configured) a webhook.
+type Event struct {
+	Time   time.Time `json:"time"`
+	Type   EventType `json:"type"`
+	RailID string    `json:"railId"`
+	Detail string    `json:"detail,omitempty"`
+
+	TransactionHash    string `json:"transactionHash,omitempty"`
+	TotalSettledAmount string `json:"totalSettledAmount,omitempty"`
+	FinalSettledEpoch  string `json:"finalSettledEpoch,omitempty"`
+}
+
+// defaultEmit writes e as a JSON line to stdout and, if cfg.WebhookURL is
+// set, POSTs it there. A webhook delivery failure is logged but never blocks
+// or retries, so a flaky webhook endpoint can't stall the watcher's
+// settlement loop.
+func (w *Watcher) defaultEmit(e Event) {
+	line, err := json.Marshal(e)
+	if err != nil {
+		w.log.Error("marshaling event", "error", err)
+		return
+	}
+	fmt.Println(string(line))
+
+	if w.cfg.WebhookURL == "" {
+		return
+	}
+	resp, err := http.Post(w.cfg.WebhookURL, "application/json", bytes.NewReader(line))
+	if err != nil {
+		w.log.Warn("delivering event to webhook", "url", w.cfg.WebhookURL, "error", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		w.log.Warn("webhook returned non-2xx status", "url", w.cfg.WebhookURL, "status", resp.StatusCode)
+	}
+}
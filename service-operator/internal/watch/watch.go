@@ -0,0 +1,283 @@
+// Package watch implements a long-lived process for service-operator that
+// polls the payer's active payment rails on an interval and settles them
+// automatically once they cross configurable thresholds, emitting a
+// structured event for every settlement decision to stdout and, if
+// configured, a webhook.
+//
+// Unlike the daemon package (which subscribes to Payments contract events on
+// behalf of a service provider being paid), watch polls
+// contract.EnumerateActiveProviderRails on behalf of the payer, so it sees
+// every rail across every registered storage node without needing a log
+// subscription.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/storacha/filecoin-services/service-operator/internal/contract"
+)
+
+// Config configures a Watcher's settlement policy and endpoints.
+type Config struct {
+	RPCUrl                 string
+	PaymentsAddress        common.Address
+	TokenAddress           common.Address
+	ServiceRegistryAddress common.Address
+	TxSigner               contract.TxSigner
+	ChainID                *big.Int
+
+	// Interval is how often the watcher re-enumerates rails and checks the
+	// settlement thresholds below.
+	Interval time.Duration
+
+	// SettleWhenLagEpochs settles a rail once it has this many unsettled
+	// epochs since its last settlement. Unset disables this trigger.
+	SettleWhenLagEpochs *big.Int
+	// SettleWhenUnsettledValue settles a rail early, even if
+	// SettleWhenLagEpochs hasn't been reached, once a cheap simulation
+	// shows it would already clear this settlement amount (token base
+	// units). Unset disables this trigger.
+	SettleWhenUnsettledValue *big.Int
+	// MinAvailableFunds, if set, skips settlement entirely (emitting a
+	// "funds_low" event instead) once the payer's free balance in the
+	// Payments contract drops below it - settling when there's nothing
+	// left to top up the rail from would just strand the transaction.
+	MinAvailableFunds *big.Int
+	// MaxGasPrice, if set, caps the gas price settlement transactions are
+	// signed with; the actual price used is min(suggested, MaxGasPrice).
+	MaxGasPrice *big.Int
+
+	// WebhookURL, if non-empty, receives a POST of each emitted Event as a
+	// JSON body.
+	WebhookURL string
+
+	// RailOpts controls pagination, concurrency, and caching for the
+	// per-tick provider/rail enumeration; see contract.EnumerateRailsOptions.
+	RailOpts contract.EnumerateRailsOptions
+
+	Logger *slog.Logger
+}
+
+// railState tracks what the watcher currently knows about one rail, across
+// poll ticks.
+type railState struct {
+	SettledUpToEpoch *big.Int
+	Terminated       bool
+}
+
+// Watcher polls the payer's active rails and settles them according to
+// Config's thresholds.
+type Watcher struct {
+	cfg Config
+	log *slog.Logger
+
+	mu    sync.Mutex
+	rails map[string]*railState
+
+	emit func(Event)
+}
+
+// New constructs a Watcher from cfg. cfg.Logger defaults to slog.Default()
+// when nil.
+func New(cfg Config) *Watcher {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	w := &Watcher{
+		cfg:   cfg,
+		log:   logger,
+		rails: make(map[string]*railState),
+	}
+	w.emit = w.defaultEmit
+	return w
+}
+
+// Run polls cfg.Interval until ctx is cancelled, settling rails as they
+// cross the configured thresholds. RPC failures back off exponentially
+// rather than returning an error, so a transient outage doesn't take the
+// watcher down.
+func (w *Watcher) Run(ctx context.Context) error {
+	backoff := newBackoff()
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	w.tick(ctx, backoff)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			w.tick(ctx, backoff)
+		}
+	}
+}
+
+func (w *Watcher) tick(ctx context.Context, backoff *backoff) {
+	client, err := ethclient.DialContext(ctx, w.cfg.RPCUrl)
+	if err != nil {
+		delay := backoff.next()
+		w.log.Error("connecting to RPC for poll tick, backing off", "error", err, "retry_in", delay)
+		return
+	}
+	defer client.Close()
+
+	currentEpoch, err := client.BlockNumber(ctx)
+	if err != nil {
+		delay := backoff.next()
+		w.log.Error("getting current block number, backing off", "error", err, "retry_in", delay)
+		return
+	}
+
+	availableFunds, err := w.queryAvailableFunds(ctx)
+	if err != nil {
+		delay := backoff.next()
+		w.log.Error("querying available funds, backing off", "error", err, "retry_in", delay)
+		return
+	}
+
+	activeRails, err := contract.EnumerateActiveProviderRails(ctx, w.cfg.RPCUrl, w.cfg.ServiceRegistryAddress, w.cfg.PaymentsAddress, w.cfg.TokenAddress, w.cfg.RailOpts)
+	if err != nil {
+		delay := backoff.next()
+		w.log.Error("enumerating active provider rails, backing off", "error", err, "retry_in", delay)
+		return
+	}
+	backoff.reset()
+
+	untilEpoch := new(big.Int).SetUint64(currentEpoch)
+	w.reconcile(ctx, activeRails, untilEpoch, availableFunds)
+}
+
+func (w *Watcher) queryAvailableFunds(ctx context.Context) (*big.Int, error) {
+	client, err := ethclient.DialContext(ctx, w.cfg.RPCUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	payer := w.cfg.TxSigner.Address()
+	return contract.QueryTokenBalance(ctx, w.cfg.RPCUrl, w.cfg.TokenAddress, payer)
+}
+
+// reconcile applies newly observed rail state, settling any rail that has
+// crossed SettleWhenLagEpochs or SettleWhenUnsettledValue, unless
+// MinAvailableFunds blocks it.
+func (w *Watcher) reconcile(ctx context.Context, activeRails []contract.ActiveRail, untilEpoch, availableFunds *big.Int) {
+	for _, rail := range activeRails {
+		railID := rail.RailInfo.RailID.String()
+
+		w.mu.Lock()
+		state, known := w.rails[railID]
+		if !known {
+			state = &railState{SettledUpToEpoch: rail.RailInfo.SettledUpTo}
+			w.rails[railID] = state
+			w.log.Info("discovered rail", "rail_id", railID, "storage_node", rail.RailInfo.To.Hex())
+		}
+		state.SettledUpToEpoch = rail.RailInfo.SettledUpTo
+		state.Terminated = rail.RailInfo.IsTerminated
+		w.mu.Unlock()
+
+		if rail.RailInfo.IsTerminated {
+			continue
+		}
+
+		due, reason := w.isDue(ctx, rail, untilEpoch)
+		if !due {
+			continue
+		}
+
+		if w.cfg.MinAvailableFunds != nil && availableFunds.Cmp(w.cfg.MinAvailableFunds) < 0 {
+			w.emitEvent(Event{
+				Type:   EventFundsLow,
+				RailID: railID,
+				Detail: fmt.Sprintf("available funds %s below min-available-funds %s, skipping settlement", availableFunds.String(), w.cfg.MinAvailableFunds.String()),
+			})
+			continue
+		}
+
+		w.settle(ctx, rail, untilEpoch, reason)
+	}
+}
+
+// isDue reports whether rail has crossed SettleWhenLagEpochs or (via a cheap
+// simulation) SettleWhenUnsettledValue, and a human-readable reason.
+func (w *Watcher) isDue(ctx context.Context, rail contract.ActiveRail, untilEpoch *big.Int) (bool, string) {
+	lagEpochs := new(big.Int).Sub(untilEpoch, rail.RailInfo.SettledUpTo)
+	if w.cfg.SettleWhenLagEpochs != nil && lagEpochs.Cmp(w.cfg.SettleWhenLagEpochs) >= 0 {
+		return true, fmt.Sprintf("lag %s epochs >= --settle-when-lag-epochs %s", lagEpochs.String(), w.cfg.SettleWhenLagEpochs.String())
+	}
+	if w.cfg.SettleWhenUnsettledValue == nil {
+		return false, ""
+	}
+
+	simulated, err := contract.SimulateSettleRail(ctx, w.cfg.RPCUrl, w.cfg.PaymentsAddress, w.cfg.TxSigner.Address(), rail.RailInfo.RailID, untilEpoch, "pending")
+	if err != nil {
+		w.log.Warn("simulating settlement to check --settle-when-unsettled-value", "rail_id", rail.RailInfo.RailID.String(), "error", err)
+		return false, ""
+	}
+	if simulated.TotalSettledAmount.Cmp(w.cfg.SettleWhenUnsettledValue) >= 0 {
+		return true, fmt.Sprintf("projected settlement %s >= --settle-when-unsettled-value %s", simulated.TotalSettledAmount.String(), w.cfg.SettleWhenUnsettledValue.String())
+	}
+	return false, ""
+}
+
+// settle simulates railID's settlement via SimulateSettleRail as a
+// pre-flight check before broadcasting, so a rail that would revert
+// (insufficient funds, a just-terminated rail, etc.) is caught and reported
+// as skipped instead of wasting a transaction.
+func (w *Watcher) settle(ctx context.Context, rail contract.ActiveRail, untilEpoch *big.Int, reason string) {
+	railID := rail.RailInfo.RailID.String()
+
+	if _, err := contract.SimulateSettleRail(ctx, w.cfg.RPCUrl, w.cfg.PaymentsAddress, w.cfg.TxSigner.Address(), rail.RailInfo.RailID, untilEpoch, "pending"); err != nil {
+		w.emitEvent(Event{Type: EventSkipped, RailID: railID, Detail: fmt.Sprintf("pre-flight simulation failed, not broadcasting: %v", err)})
+		return
+	}
+
+	auth := contract.CreateTransactorFromSigner(ctx, w.cfg.TxSigner, w.cfg.ChainID)
+
+	if w.cfg.MaxGasPrice != nil {
+		client, err := ethclient.DialContext(ctx, w.cfg.RPCUrl)
+		if err != nil {
+			w.log.Error("connecting to RPC to suggest gas price", "rail_id", railID, "error", err)
+			return
+		}
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		client.Close()
+		if err != nil {
+			w.log.Error("suggesting gas price", "rail_id", railID, "error", err)
+			return
+		}
+		if gasPrice.Cmp(w.cfg.MaxGasPrice) > 0 {
+			gasPrice = w.cfg.MaxGasPrice
+		}
+		auth.GasPrice = gasPrice
+	}
+
+	result, err := contract.SettleRail(ctx, w.cfg.RPCUrl, w.cfg.PaymentsAddress, auth, rail.RailInfo.RailID, untilEpoch)
+	if err != nil {
+		w.emitEvent(Event{Type: EventSkipped, RailID: railID, Detail: fmt.Sprintf("settlement failed: %v", err)})
+		return
+	}
+
+	w.emitEvent(Event{
+		Type:               EventSettled,
+		RailID:             railID,
+		Detail:             reason,
+		TransactionHash:    result.TransactionHash.Hex(),
+		TotalSettledAmount: result.TotalSettledAmount.String(),
+		FinalSettledEpoch:  result.FinalSettledEpoch.String(),
+	})
+}
+
+func (w *Watcher) emitEvent(e Event) {
+	e.Time = time.Now()
+	w.emit(e)
+}